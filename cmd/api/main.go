@@ -1,17 +1,43 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"module.resume/internal/config"
 	"module.resume/internal/container"
+	"module.resume/internal/infrastructure/logging"
 )
 
 func main() {
-	c, err := container.NewContainer()
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
 		log.Fatal(err)
 	}
-	if err := c.Router.Run(":8080"); err != nil {
-		log.Fatalf("Failed to run server: %v", err)
+
+	logger := logging.New(cfg.Log)
+	slog.SetDefault(logger)
+
+	c, err := container.NewContainer(cfg, logger)
+	if err != nil {
+		logger.Error("Failed to initialize", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	runErr := c.Run(ctx)
+	if err := c.Close(); err != nil {
+		logger.Error("Failed to release resources", "error", err)
+	}
+	if runErr != nil {
+		logger.Error("Failed to run server", "error", runErr)
+		os.Exit(1)
 	}
+	logger.Info("Server stopped")
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"module.resume/internal/application"
+	"module.resume/internal/batch"
+	"module.resume/internal/config"
+	"module.resume/internal/container"
+	"module.resume/internal/infrastructure/logging"
+)
+
+// 사용법: batch [flags]            스케줄대로 잡을 실행하고 작업 큐를 처리
+//
+//	batch [flags] run NAME   잡 하나를 바로 한 번 실행
+func main() {
+	cfg, args, err := config.LoadWithArgs(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger := logging.New(cfg.Log)
+	slog.SetDefault(logger)
+
+	core, err := container.NewCore(cfg, logger)
+	if err != nil {
+		logger.Error("Failed to initialize", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	runErr := run(ctx, core, args)
+	if err := core.Close(); err != nil {
+		logger.Error("Failed to release resources", "error", err)
+	}
+	if runErr != nil {
+		logger.Error("Batch failed", "error", runErr)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, core *container.Core, args []string) error {
+	cfg := core.Config
+	runner, err := batch.NewRunner(core.DB, core.Log, cfg.Batch.JobTimeout,
+		batch.PurgeDeletedUsersJob(core.Users, cfg.Batch.PurgeDeletedUsersSchedule, cfg.Batch.DeletedUserRetention, core.Log),
+		// 이력서, 공유 링크처럼 사용자 데이터를 가진 저장소가 생기면 여기에 eraser 로 추가한다
+		batch.AnonymizeDeletedUsersJob(core.Users, core.TxManager, core.Outbox,
+			cfg.Batch.AnonymizeDeletedUsersSchedule, cfg.Account.DeletionGracePeriod, core.Log,
+			core.Outbox, core.Exports, core.EmailChanges, core.Audits, core.NewAvatarService(),
+		),
+		batch.PurgeExpiredExportsJob(core.Exports, cfg.Batch.PurgeExpiredExportsSchedule, core.Log),
+	)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(args) == 0:
+		pool := batch.NewWorkerPool(core.Queue, core.Log, cfg.Batch.QueueWorkers, cfg.Batch.QueuePollInterval, cfg.Batch.TaskTimeout)
+		pool.Handle(application.TaskBuildExport, batch.BuildExportHandler(core.NewExportService()))
+
+		relay := batch.NewOutboxRelay(core.Outbox, core.TxManager, core.Log,
+			cfg.Batch.OutboxBatchSize, cfg.Batch.OutboxPollInterval, cfg.Batch.OutboxMaxAttempts, sinks(cfg, core)...)
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			runner.Run(ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			pool.Run(ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			relay.Run(ctx)
+		}()
+		wg.Wait()
+		return nil
+	case len(args) == 2 && args[0] == "run":
+		return runner.RunOnce(ctx, args[1])
+	default:
+		return fmt.Errorf("usage: batch [flags] [run NAME]")
+	}
+}
+
+func sinks(cfg *config.Config, core *container.Core) []batch.Sink {
+	if cfg.Batch.OutboxWebhookURL == "" {
+		return []batch.Sink{batch.NewLogSink(core.Log)}
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	return []batch.Sink{batch.NewWebhookSink(cfg.Batch.OutboxWebhookURL, cfg.Batch.OutboxWebhookSecret, client)}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+
+	"module.resume/internal/config"
+	"module.resume/internal/infrastructure/logging"
+	"module.resume/internal/infrastructure/persistence/gorm"
+	"module.resume/internal/infrastructure/persistence/migrations"
+)
+
+const usage = `usage: migrate [flags] <command>
+
+commands:
+  up           apply all pending migrations
+  down N       revert the last N applied migrations
+  status       list migrations and when they were applied
+  create NAME  add an empty up/down pair to the source directory
+
+flags:
+`
+
+func main() {
+	var dir string
+	cfg, args, err := config.LoadWithFlags(os.Args[1:], func(fs *flag.FlagSet) {
+		fs.StringVar(&dir, "dir", migrations.SourceDir, "migration source directory used by create")
+		fs.Usage = func() {
+			fmt.Fprint(fs.Output(), usage)
+			fs.PrintDefaults()
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger := logging.New(cfg.Log)
+	slog.SetDefault(logger)
+
+	if err := run(args, dir, cfg, logger); err != nil {
+		logger.Error("Migration failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, dir string, cfg *config.Config, logger *slog.Logger) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", usage)
+	}
+
+	// create 는 DB 없이 파일만 만든다
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return fmt.Errorf("usage: migrate create NAME")
+		}
+		files, err := migrations.Create(dir, args[1])
+		for _, f := range files {
+			fmt.Println("created", f)
+		}
+		return err
+	}
+
+	db, err := gorm.NewDB(cfg.Database, cfg.Log, logger)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	migrator, err := migrations.New(sqlDB, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		logger.Info("Migrations applied", "count", applied)
+		return err
+	case "down":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: migrate down N")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("N must be a positive integer (got %q)", args[1])
+		}
+		reverted, err := migrator.Down(ctx, n)
+		logger.Info("Migrations reverted", "count", reverted)
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
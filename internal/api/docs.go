@@ -0,0 +1,35 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/api/middleware"
+	"module.resume/internal/api/openapi"
+)
+
+// signedLink 는 메일로 보내는 서명된 링크의 쿼리 (auth.URLSigner)
+type signedLink struct {
+	Expires   string `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+// apiRoutes 는 gin 에 라우트를 등록하면서 그 문서도 모은다. /openapi.json 은 여기 모인 것으로 만든다
+type apiRoutes struct {
+	docs []openapi.Route
+}
+
+// handle 은 doc.Method, doc.Path 로 라우트를 등록한다. doc.Path 는 group 기준의 상대 경로다
+func (a *apiRoutes) handle(group *gin.RouterGroup, doc openapi.Route, handlers ...gin.HandlerFunc) {
+	group.Handle(doc.Method, doc.Path, handlers...)
+	doc.Path = strings.TrimSuffix(group.BasePath(), "/") + doc.Path
+	a.docs = append(a.docs, doc)
+}
+
+// spec 은 지금까지 등록한 라우트의 문서
+func (a *apiRoutes) spec() *openapi.Document {
+	return openapi.Build(openapi.Info{
+		Title:   "module-resume API",
+		Version: "1.0",
+	}, middleware.Problem{}, a.docs)
+}
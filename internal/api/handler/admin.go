@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/api/request"
+	"module.resume/internal/api/response"
+	"module.resume/internal/application"
+	"module.resume/internal/listing"
+)
+
+type AdminHandler struct {
+	service application.AdminService
+	cursors *listing.Codec
+}
+
+func NewAdminHandler(service application.AdminService, cursors *listing.Codec) *AdminHandler {
+	return &AdminHandler{
+		service,
+		cursors,
+	}
+}
+
+// Users 는 사용자 검색. 필터와 정렬은 request.UserSearch 를 따른다
+func (h *AdminHandler) Users(c *gin.Context) {
+	spec, err := listing.Parse(c.Request.URL.Query(), request.UserSearch, h.cursors)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	page, err := h.service.SearchUsers(c.Request.Context(), spec)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]response.AdminUser, 0, len(page.Items))
+	for _, u := range page.Items {
+		items = append(items, response.FromAdminUser(u))
+	}
+	c.JSON(http.StatusOK, listResponse(c, h.cursors, spec, page.Next, items))
+}
+
+// listResponse 는 다음 페이지가 있으면 Link 헤더를 달고 커서를 본문에도 넣는다
+func listResponse[T any](c *gin.Context, cursors *listing.Codec, spec listing.Spec, next *listing.Key, items []T) response.List[T] {
+	cursor := cursors.Encode(spec, next)
+	if cursor != "" {
+		c.Header("Link", listing.NextLink(c.Request.URL, cursor))
+	}
+	return response.List[T]{Items: items, NextCursor: cursor}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/api/request"
+	"module.resume/internal/api/response"
+	"module.resume/internal/application"
+)
+
+type AuditHandler struct {
+	log application.AuditLog
+}
+
+func NewAuditHandler(log application.AuditLog) *AuditHandler {
+	return &AuditHandler{
+		log,
+	}
+}
+
+// Mine 은 로그인한 사용자 계정의 최근 보안 기록을 최신순으로 돌려준다
+func (h *AuditHandler) Mine(c *gin.Context) {
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	query := request.SecurityEvents{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	entries, err := h.log.ForUser(c.Request.Context(), id, query.Limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromSecurityEvents(entries))
+}
+
+// Search 는 관리자용 조회. 조건을 모두 AND 로 묶는다
+func (h *AuditHandler) Search(c *gin.Context) {
+	query := request.AuditSearch{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	filter, err := query.ToFilter()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	entries, err := h.log.Search(c.Request.Context(), filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromAuditEvents(entries))
+}
@@ -22,12 +22,12 @@ func NewAuthHandler(service application.AuthService) *AuthHandler {
 func (a *AuthHandler) Login(c *gin.Context) {
 	loginRequest := &request.LoginRequest{}
 	if err := c.ShouldBindJSON(loginRequest); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, err)
+		_ = c.Error(err)
 		return
 	}
 	token, err := a.service.Login(c.Request.Context(), loginRequest.ToDomain())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, err)
+		_ = c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"accessToken": token})
@@ -39,7 +39,7 @@ func (a *AuthHandler) Logout(c *gin.Context) {
 
 	err := a.service.Logout(c.Request.Context(), tokenString)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/api/response"
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+)
+
+// multipart 경계와 헤더에 쓰이는 여유분
+const multipartOverhead = 64 << 10
+
+type AvatarHandler struct {
+	service  application.AvatarService
+	maxBytes int64
+}
+
+func NewAvatarHandler(service application.AvatarService, maxBytes int) *AvatarHandler {
+	return &AvatarHandler{
+		service,
+		int64(maxBytes),
+	}
+}
+
+// Upload 는 multipart 의 avatar 파일을 프로필 사진으로 저장한다
+func (h *AvatarHandler) Upload(c *gin.Context) {
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// 다 읽기 전에 끊어서 큰 본문이 디스크나 메모리를 채우지 않게 한다
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverhead)
+	file, err := c.FormFile("avatar")
+	var tooLarge *http.MaxBytesError
+	if err != nil && !errors.As(err, &tooLarge) {
+		err = domain.Validation("request validation failed", domain.FieldError{Field: "avatar", Message: "is required"})
+	}
+	if err == nil && file.Size > h.maxBytes {
+		err = &http.MaxBytesError{Limit: h.maxBytes}
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	updated, err := h.service.Upload(c.Request.Context(), id, data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body := response.FromUser(updated)
+	body.AvatarUrl = h.service.URL(updated)
+	c.JSON(http.StatusOK, body)
+}
+
+func (h *AvatarHandler) Remove(c *gin.Context) {
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.Remove(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Download 는 응답에 담아 준 서명된 링크로 들어오므로 토큰 없이 서명만 확인한다
+// key 는 업로드마다 바뀌므로 링크가 유효한 동안은 캐시해도 된다
+func (h *AvatarHandler) Download(c *gin.Context) {
+	blob, err := h.service.Open(c.Request.Context(), c.Param("id"), c.Param("name"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer blob.Body.Close()
+
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, blob.Size, blob.ContentType, blob.Body, nil)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/api/request"
+	"module.resume/internal/application"
+)
+
+type EmailChangeHandler struct {
+	service application.EmailChangeService
+}
+
+func NewEmailChangeHandler(service application.EmailChangeService) *EmailChangeHandler {
+	return &EmailChangeHandler{
+		service,
+	}
+}
+
+// Request 는 email 변경을 요청한다. 새 주소에서 확인하기 전까지 email 은 그대로다
+func (h *EmailChangeHandler) Request(c *gin.Context) {
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	requestChange := request.ChangeEmail{}
+	if err := c.ShouldBindJSON(&requestChange); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.Request(c.Request.Context(), id, requestChange.Email, requestChange.Password); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// Confirm 은 새 주소로 받은 토큰으로 변경을 확정한다. 모든 토큰이 무효가 되므로 다시 로그인해야 한다
+func (h *EmailChangeHandler) Confirm(c *gin.Context) {
+	requestToken := request.EmailChangeToken{}
+	if err := c.ShouldBindJSON(&requestToken); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if _, err := h.service.Confirm(c.Request.Context(), requestToken.Token); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Undo 는 예전 주소로 받은 토큰으로 변경을 취소하거나 되돌린다
+func (h *EmailChangeHandler) Undo(c *gin.Context) {
+	requestToken := request.EmailChangeToken{}
+	if err := c.ShouldBindJSON(&requestToken); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if _, err := h.service.Undo(c.Request.Context(), requestToken.Token); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
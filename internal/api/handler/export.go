@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+)
+
+type ExportHandler struct {
+	service application.ExportService
+}
+
+func NewExportHandler(service application.ExportService) *ExportHandler {
+	return &ExportHandler{
+		service,
+	}
+}
+
+// Request 는 데이터 내보내기를 시작한다. 준비되면 다운로드 링크가 메일로 간다
+func (h *ExportHandler) Request(c *gin.Context) {
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	requested, err := h.service.Request(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"id": requested.ID, "status": requested.Status})
+}
+
+// Download 는 메일로 보낸 서명된 링크로 들어오므로 토큰 없이 서명만 확인한다
+func (h *ExportHandler) Download(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		_ = c.Error(domain.NotFound("export not found"))
+		return
+	}
+
+	archive, err := h.service.Download(c.Request.Context(), id, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", `attachment; filename="export-`+c.Param("id")+`.zip"`)
+	c.Data(http.StatusOK, "application/zip", archive)
+}
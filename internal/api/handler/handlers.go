@@ -1,6 +1,15 @@
 package handler
 
+import "net/http"
+
 type Handlers struct {
-	User *UserHandler
-	Auth *AuthHandler
+	User    *UserHandler
+	Auth    *AuthHandler
+	Export  *ExportHandler
+	Email   *EmailChangeHandler
+	Avatar  *AvatarHandler
+	Audit   *AuditHandler
+	Admin   *AdminHandler
+	Health  *HealthHandler
+	Metrics http.Handler
 }
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type readyResponse struct {
+	Status string                      `json:"status"`
+	Checks map[string]dependencyStatus `json:"checks"`
+}
+
+func serveReady(t *testing.T, h *HealthHandler) (int, readyResponse) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/readyz", h.Ready)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var body readyResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w.Code, body
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	ok := HealthCheck{Name: "postgres", Timeout: time.Second, Check: func(ctx context.Context) error { return nil }}
+
+	t.Run("all dependencies up", func(t *testing.T) {
+		code, body := serveReady(t, NewHealthHandler(ok))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ok", body.Status)
+		assert.Equal(t, "ok", body.Checks["postgres"].Status)
+	})
+
+	t.Run("dependency down", func(t *testing.T) {
+		down := HealthCheck{Name: "redis", Timeout: time.Second, Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}
+
+		code, body := serveReady(t, NewHealthHandler(ok, down))
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "unavailable", body.Status)
+		assert.Equal(t, "ok", body.Checks["postgres"].Status)
+		assert.Equal(t, "connection refused", body.Checks["redis"].Error)
+	})
+
+	t.Run("check timeout", func(t *testing.T) {
+		slow := HealthCheck{Name: "redis", Timeout: 10 * time.Millisecond, Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+
+		code, body := serveReady(t, NewHealthHandler(slow))
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, context.DeadlineExceeded.Error(), body.Checks["redis"].Error)
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		h := NewHealthHandler(ok)
+		h.SetDraining()
+
+		code, body := serveReady(t, h)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "draining", body.Status)
+	})
+}
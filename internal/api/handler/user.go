@@ -1,78 +1,190 @@
 package handler
 
 import (
-	"context"
-	"errors"
+	"encoding/json"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"module.resume/internal/api/request"
+	"module.resume/internal/api/response"
 	"module.resume/internal/application"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/user"
 )
 
 type UserHandler struct {
 	service application.UserService
+	avatars application.AvatarService
 }
 
-func NewUserHandler(service application.UserService) *UserHandler {
+func NewUserHandler(service application.UserService, avatars application.AvatarService) *UserHandler {
 	return &UserHandler{
 		service,
+		avatars,
 	}
 }
 
 func (h *UserHandler) Save(c *gin.Context) {
 	requestUser := request.SaveUser{}
 	if err := c.ShouldBindJSON(&requestUser); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, err)
+		_ = c.Error(err)
 		return
 	}
 
 	domainUser, err := requestUser.ToDomain()
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, err)
+		_ = c.Error(err)
+		return
 	}
 	userId, err := h.service.Save(c.Request.Context(), domainUser)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			c.JSON(http.StatusGatewayTimeout, gin.H{
-				"error": "Database operation timed out",
-			})
-			return
-		}
-
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, userId)
 }
 
+// Me 는 로그인한 사용자 자신의 프로필을 돌려준다
+func (h *UserHandler) Me(c *gin.Context) {
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	found, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.profile(found))
+}
+
+// Patch 는 JSON Merge Patch 로 보낸 필드만 바꾼다. 모르는 필드(email 등)가 있으면 거부한다
+func (h *UserHandler) Patch(c *gin.Context) {
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	requestPatch := request.PatchUser{}
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&requestPatch); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := requestPatch.Validate(); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	patched, err := h.service.Patch(c.Request.Context(), id, requestPatch.ToPatch())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.profile(patched))
+}
+
+// Update 는 로그인한 사용자의 프로필을 통째로 바꾼다. 본문의 id/email 은 받지 않는다
 func (h *UserHandler) Update(c *gin.Context) {
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	requestUser := request.UpdateUser{}
 	if err := c.ShouldBindJSON(&requestUser); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, err)
+		_ = c.Error(err)
 		return
 	}
 
-	domainUser := requestUser.ToDomain()
-	userId, err := h.service.Update(c.Request.Context(), domainUser)
+	updated, err := h.service.Patch(c.Request.Context(), id, requestUser.ToPatch())
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			c.JSON(http.StatusGatewayTimeout, gin.H{
-				"error": "Database operation timed out",
-			})
-			return
-		}
-
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusAccepted, userId)
+	c.JSON(http.StatusAccepted, updated.ID)
 }
 
+// UpdatePassword 는 비밀번호를 바꾸고 지금 쓰던 토큰을 포함한 모든 토큰을 무효로 만든다
 func (h *UserHandler) UpdatePassword(c *gin.Context) {
+	requestPassword := request.UpdateUserPassword{}
+	if err := c.ShouldBindJSON(&requestPassword); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.service.UpdatePassword(c.Request.Context(), id, requestPassword.CurrentPassword, requestPassword.NewPassword)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
 
+// Delete 는 비밀번호를 다시 확인하고 탈퇴 처리한다. 유예 기간 안에는 CancelDeletion 으로 되돌릴 수 있다
 func (h *UserHandler) Delete(c *gin.Context) {
+	requestDelete := request.DeleteUser{}
+	if err := c.ShouldBindJSON(&requestDelete); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	id, err := userID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id, requestDelete.Password); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) CancelDeletion(c *gin.Context) {
+	requestCancel := request.CancelUserDeletion{}
+	if err := c.ShouldBindJSON(&requestCancel); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.CancelDeletion(c.Request.Context(), requestCancel.Email, requestCancel.Password); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) profile(u *user.User) response.User {
+	body := response.FromUser(u)
+	body.AvatarUrl = h.avatars.URL(u)
+	return body
+}
+
+// userID 는 AuthMiddleware 가 토큰에서 꺼내 둔 사용자 ID 를 돌려준다
+func userID(c *gin.Context) (uint, error) {
+	id := c.GetUint("user_id")
+	if id == 0 {
+		return 0, domain.Unauthorized("token has no user id, please log in again")
+	}
+	return id, nil
 }
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/domain"
+)
+
+// AdminMiddleware 는 설정된 관리자 ID 로 로그인한 요청만 통과시킨다. AuthMiddleware 다음에 와야 한다
+func AdminMiddleware(adminUserIDs []uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetUint("user_id")
+		if userID == 0 || !slices.Contains(adminUserIDs, userID) {
+			_ = c.Error(domain.Forbidden("admin access required"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
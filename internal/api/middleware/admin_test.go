@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	serve := func(userID uint, email string) int {
+		r := gin.New()
+		r.Use(ErrorMiddleware())
+		r.Use(func(c *gin.Context) {
+			if userID != 0 {
+				c.Set("user_id", userID)
+				c.Set("email", email)
+			}
+		})
+		r.Use(AdminMiddleware([]uint{7}))
+		r.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(7, "admin@example.com"))
+	// 관리자가 쓰던 email 을 다른 계정이 가져가도 관리자가 되지 않는다
+	assert.Equal(t, http.StatusForbidden, serve(8, "admin@example.com"))
+	assert.Equal(t, http.StatusForbidden, serve(0, ""))
+}
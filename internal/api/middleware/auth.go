@@ -1,27 +1,42 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm/utils"
 	"module.resume/internal/application"
+	"module.resume/internal/domain"
+	"module.resume/internal/infrastructure/logging"
 )
 
 func AuthMiddleware(authService application.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token, exists := c.Get("token")
 		if !exists || token.(string) == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization token required"})
+			_ = c.Error(domain.Unauthorized("authorization token required"))
+			c.Abort()
 			return
 		}
 
 		claims, err := authService.Authenticate(c, utils.ToString(token))
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			_ = c.Error(authError(err))
+			c.Abort()
 			return
 		}
 		c.Set("email", claims.Subject)
+		c.Set("user_id", claims.UserID)
+		ctx := logging.With(c.Request.Context(), "user", claims.Subject)
+		c.Request = c.Request.WithContext(application.WithActor(ctx, claims.UserID))
 		c.Next()
 	}
 }
+
+// 캐시 장애 같은 내부 에러는 그대로 두고, 토큰 자체의 문제만 401 로 바꾼다
+func authError(err error) error {
+	if errors.Is(err, domain.ErrUnauthorized) {
+		return domain.Unauthorized("invalid or expired token")
+	}
+	return err
+}
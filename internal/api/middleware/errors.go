@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"module.resume/internal/domain"
+	"module.resume/internal/infrastructure/logging"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem 은 RFC 7807 응답 본문
+type Problem struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []domain.FieldError `json:"errors,omitempty"`
+}
+
+// ErrorMiddleware 는 핸들러가 c.Error 로 남긴 마지막 에러를 problem+json 으로 변환한다
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		problem := toProblem(err)
+		problem.Instance = c.Request.URL.Path
+		problem.RequestID = logging.RequestID(c.Request.Context())
+
+		if problem.Status >= http.StatusInternalServerError {
+			ctx := c.Request.Context()
+			logging.FromContext(ctx).ErrorContext(ctx, "unhandled error", slog.String("error", err.Error()))
+		}
+
+		c.Header("Content-Type", problemContentType)
+		c.AbortWithStatus(problem.Status)
+		_ = json.NewEncoder(c.Writer).Encode(problem)
+	}
+}
+
+func toProblem(err error) Problem {
+	var (
+		domainErr     *domain.Error
+		validationErr validator.ValidationErrors
+		syntaxErr     *json.SyntaxError
+		typeErr       *json.UnmarshalTypeError
+		tooLargeErr   *http.MaxBytesError
+	)
+
+	switch {
+	case errors.As(err, &validationErr):
+		return newProblem(http.StatusUnprocessableEntity, "request validation failed", fieldErrors(validationErr)...)
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return newProblem(http.StatusBadRequest, "request body is not valid JSON")
+	case errors.As(err, &typeErr):
+		return newProblem(http.StatusBadRequest, fmt.Sprintf("%s has an invalid type", typeErr.Field))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json 은 이 에러의 타입을 따로 두지 않는다
+		return newProblem(http.StatusBadRequest, strings.TrimPrefix(err.Error(), "json: ")+" is not allowed")
+	case errors.As(err, &tooLargeErr):
+		return newProblem(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must not exceed %d bytes", tooLargeErr.Limit))
+	case errors.Is(err, context.DeadlineExceeded):
+		return newProblem(http.StatusGatewayTimeout, "operation timed out")
+	}
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, domain.ErrForbidden):
+		status = http.StatusForbidden
+	}
+
+	// 예상하지 못한 에러의 내용은 밖으로 노출하지 않는다
+	if status == http.StatusInternalServerError {
+		return newProblem(status, "internal server error")
+	}
+
+	problem := newProblem(status, err.Error())
+	if errors.As(err, &domainErr) {
+		problem.Errors = domainErr.Fields
+	}
+	return problem
+}
+
+func newProblem(status int, detail string, fields ...domain.FieldError) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Errors: fields,
+	}
+}
+
+func fieldErrors(errs validator.ValidationErrors) []domain.FieldError {
+	fields := make([]domain.FieldError, 0, len(errs))
+	for _, fe := range errs {
+		fields = append(fields, domain.FieldError{
+			Field:   fe.Field(),
+			Message: validationMessage(fe),
+		})
+	}
+	return fields
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "min":
+		return fmt.Sprintf("must be at least %s characters", fe.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s characters", fe.Param())
+	case "eqfield":
+		return fmt.Sprintf("must match %s", lowerFirst(fe.Param()))
+	default:
+		return fmt.Sprintf("failed on %q validation", fe.Tag())
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+)
+
+func serveError(t *testing.T, err error) (*httptest.ResponseRecorder, Problem) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ErrorMiddleware())
+	r.GET("/boom", func(c *gin.Context) {
+		_ = c.Error(err)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return w, problem
+}
+
+func TestErrorMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		detail string
+	}{
+		{"not found", domain.NotFound("user not found"), http.StatusNotFound, "user not found"},
+		{"conflict", fmt.Errorf("save: %w", domain.Conflict("email already registered")), http.StatusConflict, "save: email already registered"},
+		{"unauthorized", domain.Unauthorized("invalid password"), http.StatusUnauthorized, "invalid password"},
+		{"forbidden", domain.ErrForbidden, http.StatusForbidden, "forbidden"},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, "operation timed out"},
+		{"unknown json field", errors.New(`json: unknown field "email"`), http.StatusBadRequest, `unknown field "email" is not allowed`},
+		{"body too large", fmt.Errorf("multipart: %w", &http.MaxBytesError{Limit: 1024}), http.StatusRequestEntityTooLarge, "request body must not exceed 1024 bytes"},
+		{"unknown error is hidden", errors.New("pq: connection refused"), http.StatusInternalServerError, "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, problem := serveError(t, tt.err)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, http.StatusText(tt.status), problem.Title)
+			assert.Equal(t, tt.detail, problem.Detail)
+			assert.Equal(t, "/boom", problem.Instance)
+		})
+	}
+
+	t.Run("validation fields", func(t *testing.T) {
+		err := domain.Validation("invalid user", domain.FieldError{Field: "email", Message: "is required"})
+		w, problem := serveError(t, err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, []domain.FieldError{{Field: "email", Message: "is required"}}, problem.Errors)
+	})
+}
+
+func TestErrorMiddleware_Binding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	type body struct {
+		Email    string `json:"email" binding:"required,email"`
+		Password string `json:"password" binding:"required,min=12"`
+	}
+	r := gin.New()
+	r.Use(ErrorMiddleware())
+	r.POST("/", func(c *gin.Context) {
+		var b body
+		if err := c.ShouldBindJSON(&b); err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	t.Run("malformed json", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"nope","password":"short"}`)))
+
+		var problem Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Len(t, problem.Errors, 2)
+		assert.Equal(t, "must be a valid email address", problem.Errors[0].Message)
+		assert.Equal(t, "must be at least 12 characters", problem.Errors[1].Message)
+	})
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/infrastructure/logging"
+)
+
+// LoggerMiddleware 는 gin.Default 의 텍스트 로거 대신 요청마다 JSON 한 줄을 남긴다
+// 쿼리스트링에는 토큰이 들어갈 수 있어서 path 만 기록한다
+func LoggerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		route := c.FullPath()
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "route", route))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+
+		// AuthMiddleware 가 c.Request 를 바꿔 두므로 여기서 꺼낸 로거에는 user 속성이 이미 있다
+		ctx := c.Request.Context()
+		logger := logging.FromContext(ctx)
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.ErrorContext(ctx, "request", attrs...)
+		case status >= http.StatusBadRequest:
+			logger.WarnContext(ctx, "request", attrs...)
+		default:
+			logger.InfoContext(ctx, "request", attrs...)
+		}
+	}
+}
+
+func RecoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, recovered any) {
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).ErrorContext(ctx, "panic recovered", slog.Any("panic", recovered))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"module.resume/internal/config"
+	"module.resume/internal/infrastructure/logging"
+)
+
+func TestLoggerMiddleware_UserOnce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	logger := logging.NewWithWriter(&buf, config.LogConfig{Level: "info"})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), logger))
+	})
+	r.Use(LoggerMiddleware())
+	// AuthMiddleware 와 같은 방식으로 사용자를 붙인다
+	r.Use(func(c *gin.Context) {
+		c.Set("email", "kim@example.com")
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user", "kim@example.com"))
+	})
+	r.GET("/", func(c *gin.Context) {})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, 1, strings.Count(buf.String(), `"user":"kim@example.com"`), buf.String())
+}
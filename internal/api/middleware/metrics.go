@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HTTPMetricsRecorder interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+}
+
+func MetricsMiddleware(recorder HTTPMetricsRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// 실제 경로 대신 라우트 템플릿을 써야 라벨 수가 폭발하지 않는다
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		recorder.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
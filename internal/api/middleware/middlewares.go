@@ -0,0 +1,11 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+type Middlewares struct {
+	Auth    gin.HandlerFunc
+	Admin   gin.HandlerFunc
+	Timeout gin.HandlerFunc
+	Metrics gin.HandlerFunc
+	Tracing gin.HandlerFunc
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"module.resume/internal/infrastructure/logging"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestIDMiddleware())
+	var seen string
+	r.GET("/", func(c *gin.Context) {
+		seen = logging.RequestID(c.Request.Context())
+	})
+
+	t.Run("accepts incoming id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, "upstream-id-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, "upstream-id-1", seen)
+		assert.Equal(t, "upstream-id-1", w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("generates id when missing or invalid", func(t *testing.T) {
+		for _, incoming := range []string{"", "bad id\nwith newline"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(RequestIDHeader, incoming)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Len(t, seen, 32)
+			assert.NotEqual(t, incoming, seen)
+			assert.Equal(t, seen, w.Header().Get(RequestIDHeader))
+		}
+	})
+}
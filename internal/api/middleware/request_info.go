@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"module.resume/internal/application"
+	"module.resume/internal/infrastructure/logging"
+)
+
+// RequestInfoMiddleware 는 감사 기록에 남길 IP, user agent, 요청 ID 를 ctx 에 넣는다
+// IP 는 설정한 신뢰 프록시(MakeRouter)가 보낸 X-Forwarded-For 만 따른다. RequestIDMiddleware 다음에 와야 한다
+func RequestInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		c.Request = c.Request.WithContext(application.WithRequestInfo(ctx, application.RequestInfo{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: logging.RequestID(ctx),
+		}))
+		c.Next()
+	}
+}
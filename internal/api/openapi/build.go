@@ -0,0 +1,230 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"module.resume/internal/listing"
+)
+
+const (
+	jsonContentType    = "application/json"
+	problemContentType = "application/problem+json"
+	bearerAuth         = "bearerAuth"
+)
+
+// Route 는 문서에 넣을 API 하나. 본문과 쿼리 타입은 reflect 로 읽으므로 빈 값을 넘긴다
+type Route struct {
+	// Method 와 Path 는 gin 에 등록한 그대로 쓴다 (/user/avatar/:id/:name)
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+	// Auth 면 Bearer 토큰이 필요하다
+	Auth bool
+	// Query 는 form 태그가 달린 쿼리 파라미터 구조체
+	Query any
+	// Listing 은 listing.Parse 로 받는 목록 API 의 limit, cursor, sort, 필터
+	Listing *listing.Schema
+	// Body 는 JSON 본문. binding 태그가 스키마 제약이 된다
+	Body any
+	// Upload 는 multipart 로 받는 파일 필드 이름
+	Upload string
+	// Status 는 성공 응답 코드. Response 가 nil 이고 Produces 도 없으면 본문이 없다
+	Status   int
+	Response any
+	// Produces 는 JSON 이 아닌 응답 본문의 Content-Type (application/zip 등)
+	Produces string
+	// Errors 는 Auth, Body 등에서 저절로 붙는 것 외에 이 API 가 돌려주는 에러 코드
+	Errors []int
+}
+
+// Build 는 routes 로 문서를 만든다. 에러 응답은 모두 problem 타입의 problem+json 이다
+func Build(info Info, problem any, routes []Route) *Document {
+	g := newGenerator()
+	problemSchema := g.schema(reflect.TypeOf(problem), true)
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	for _, route := range routes {
+		path, params := openAPIPath(route.Path)
+		op := &Operation{
+			Summary:    route.Summary,
+			Parameters: params,
+			Responses:  map[string]Response{},
+		}
+		if route.Tag != "" {
+			op.Tags = []string{route.Tag}
+		}
+		errors := slices.Clone(route.Errors)
+		if route.Auth {
+			op.Security = []map[string][]string{{bearerAuth: {}}}
+			errors = append(errors, http.StatusUnauthorized)
+		}
+		if route.Query != nil {
+			op.Parameters = append(op.Parameters, g.queryParams(reflect.TypeOf(route.Query))...)
+			errors = append(errors, http.StatusUnprocessableEntity)
+		}
+		if route.Listing != nil {
+			op.Parameters = append(op.Parameters, listingParams(*route.Listing)...)
+			errors = append(errors, http.StatusUnprocessableEntity)
+		}
+		switch {
+		case route.Body != nil:
+			op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+				jsonContentType: {Schema: g.schema(reflect.TypeOf(route.Body), false)},
+			}}
+			errors = append(errors, http.StatusBadRequest, http.StatusUnprocessableEntity)
+		case route.Upload != "":
+			op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+				"multipart/form-data": {Schema: &Schema{
+					Type:       "object",
+					Properties: map[string]*Schema{route.Upload: {Type: "string", Format: "binary"}},
+					Required:   []string{route.Upload},
+				}},
+			}}
+			errors = append(errors, http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity)
+		}
+
+		op.Responses[strconv.Itoa(route.Status)] = g.response(route)
+		slices.Sort(errors)
+		for _, code := range slices.Compact(errors) {
+			op.Responses[strconv.Itoa(code)] = Response{
+				Description: http.StatusText(code),
+				Content:     map[string]MediaType{problemContentType: {Schema: problemSchema}},
+			}
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	}
+	return doc
+}
+
+func (g *generator) response(route Route) Response {
+	r := Response{Description: http.StatusText(route.Status)}
+	switch {
+	case route.Produces != "":
+		r.Content = map[string]MediaType{route.Produces: {Schema: &Schema{Type: "string", Format: "binary"}}}
+	case route.Response != nil:
+		schema, ok := route.Response.(*Schema)
+		if !ok {
+			schema = g.schema(reflect.TypeOf(route.Response), true)
+		}
+		r.Content = map[string]MediaType{jsonContentType: {Schema: schema}}
+	}
+	if route.Listing != nil {
+		r.Headers = map[string]Header{"Link": {
+			Description: `URL of the next page with rel="next". Absent on the last page.`,
+			Schema:      String(),
+		}}
+	}
+	return r
+}
+
+// openAPIPath 는 gin 경로(:id)를 OpenAPI 경로({id})로 바꾸고 경로 파라미터를 만든다
+func openAPIPath(path string) (string, []Parameter) {
+	var params []Parameter
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+			params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: String()})
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func (g *generator) queryParams(t reflect.Type) []Parameter {
+	var params []Parameter
+	for _, f := range reflect.VisibleFields(t) {
+		name := f.Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+		schema := g.schema(f.Type, false)
+		required := applyBinding(schema, f, t)
+		params = append(params, Parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+	return params
+}
+
+func listingParams(s listing.Schema) []Parameter {
+	var sorts []string
+	for name, field := range s.Fields {
+		if field.Sortable {
+			sorts = append(sorts, name, "-"+name)
+		}
+	}
+	slices.Sort(sorts)
+	params := []Parameter{
+		{Name: listing.ParamLimit, In: "query", Schema: &Schema{
+			Type: "integer", Minimum: ptr(1.0), Maximum: ptr(float64(s.MaxLimit)), Default: s.DefaultLimit,
+		}},
+		{Name: listing.ParamCursor, In: "query", Description: "Opaque cursor from the previous page.", Schema: String()},
+		{Name: listing.ParamSort, In: "query", Description: "Sort field, prefixed with - for descending order.",
+			Schema: &Schema{Type: "string", Enum: sorts, Default: s.DefaultSort}},
+	}
+
+	names := make([]string, 0, len(s.Filters))
+	for name := range s.Filters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		filter := s.Filters[name]
+		params = append(params, Parameter{
+			Name:        name,
+			In:          "query",
+			Description: filterDescription(filter),
+			Schema:      filterSchema(s.Fields[filter.Field].Kind, filter.Op),
+		})
+	}
+	return params
+}
+
+func filterSchema(kind listing.Kind, op listing.Op) *Schema {
+	if op == listing.Present {
+		return &Schema{Type: "boolean"}
+	}
+	switch kind {
+	case listing.Int:
+		return &Schema{Type: "integer", Format: "int64"}
+	case listing.Time:
+		return &Schema{Type: "string", Format: "date-time"}
+	case listing.Bool:
+		return &Schema{Type: "boolean"}
+	}
+	return String()
+}
+
+func filterDescription(f listing.Filter) string {
+	switch f.Op {
+	case listing.Prefix:
+		return fmt.Sprintf("Case-insensitive prefix match on %s.", f.Field)
+	case listing.Contains:
+		return fmt.Sprintf("Case-insensitive substring match on %s.", f.Field)
+	case listing.Gte:
+		return fmt.Sprintf("%s is at or after this value.", f.Field)
+	case listing.Lt:
+		return fmt.Sprintf("%s is before this value.", f.Field)
+	case listing.Present:
+		return fmt.Sprintf("true for rows with %s set, false for rows without.", f.Field)
+	}
+	return fmt.Sprintf("Exact match on %s.", f.Field)
+}
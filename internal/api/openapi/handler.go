@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	"embed"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// /docs 는 swagger-ui/ 에 넣어 둔 Swagger UI 배포본을 그대로 쓰고 외부 CDN 에서 받지 않는다 (출처와 버전은 swagger-ui/README.md)
+//
+//go:embed swagger-ui/index.html swagger-ui/swagger-initializer.js swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css
+var swaggerUI embed.FS
+
+// uiAssets 는 /docs/:file 로 내보내는 파일. 여기 없는 이름은 404 다
+var uiAssets = map[string]string{
+	"swagger-ui.css":         "text/css; charset=utf-8",
+	"swagger-ui-bundle.js":   "text/javascript; charset=utf-8",
+	"swagger-initializer.js": "text/javascript; charset=utf-8",
+}
+
+// Swagger UI 는 요소에 style 속성을 직접 넣고 아이콘을 data: URL 로 그려서 이 둘만 연다
+// 스크립트는 같은 출처의 파일만 허용하고, 인라인 스크립트와 eval 은 막는다
+const uiCSP = "default-src 'none'; script-src 'self'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; " +
+	"connect-src 'self'; base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+
+// SpecHandler 는 첫 요청에서 문서를 한 번만 만들어 직렬화해 두고 그대로 돌려준다
+// 라우트를 모두 등록한 뒤에 문서를 만들도록 build 는 요청 때 부른다
+func SpecHandler(build func() *Document) gin.HandlerFunc {
+	marshal := sync.OnceValues(func() ([]byte, error) { return json.Marshal(build()) })
+	return func(c *gin.Context) {
+		body, err := marshal()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}
+}
+
+// UIHandler 는 같은 디렉터리의 openapi.json 을 읽어 그리는 Swagger UI 페이지
+func UIHandler() gin.HandlerFunc {
+	page, err := swaggerUI.ReadFile("swagger-ui/index.html")
+	return func(c *gin.Context) {
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		uiHeaders(c)
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+	}
+}
+
+// UIAssetHandler 는 페이지가 부르는 스타일과 스크립트. 경로 파라미터 file 로 파일을 고른다
+func UIAssetHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("file")
+		contentType, ok := uiAssets[name]
+		if !ok {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		body, err := swaggerUI.ReadFile("swagger-ui/" + name)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		uiHeaders(c)
+		// 파일 이름에 버전이 없으므로 배포 뒤에 너무 오래 남지 않게 한다
+		c.Header("Cache-Control", "public, max-age=3600")
+		c.Data(http.StatusOK, contentType, body)
+	}
+}
+
+func uiHeaders(c *gin.Context) {
+	c.Header("Content-Security-Policy", uiCSP)
+	c.Header("X-Content-Type-Options", "nosniff")
+}
@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uiRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/docs", UIHandler())
+	r.GET("/docs/:file", UIAssetHandler())
+	return r
+}
+
+func TestUIHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	uiRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	// 스크립트는 모두 같은 출처의 파일이다
+	assert.Contains(t, body, `<script src="docs/swagger-ui-bundle.js"></script>`)
+	assert.Contains(t, body, `<script src="docs/swagger-initializer.js"></script>`)
+	assert.NotContains(t, body, "<script>")
+	assert.NotContains(t, body, "//")
+	csp := w.Header().Get("Content-Security-Policy")
+	assert.Contains(t, csp, "default-src 'none'")
+	assert.Contains(t, csp, "script-src 'self';")
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+}
+
+func TestUIAssetHandler(t *testing.T) {
+	r := uiRouter()
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/docs/swagger-ui-bundle.js")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/javascript; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Security-Policy"), "script-src 'self';")
+
+	w = get("/docs/swagger-ui.css")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+
+	// 목록에 없는 파일은 embed 에 있어도 내보내지 않는다
+	assert.Equal(t, http.StatusNotFound, get("/docs/index.html").Code)
+	assert.Equal(t, http.StatusNotFound, get("/docs/README.md").Code)
+}
+
+// 넣어 둔 배포본을 손대지 않았는지 swagger-ui/README.md 에 적은 해시와 비교한다
+func TestSwaggerUIPinned(t *testing.T) {
+	pinned := map[string]string{
+		"swagger-ui-bundle.js": "a600ebf8f885c92373e2210b1fd7422b24a4ff9cad93d3d7d6481f40b7704564",
+		"swagger-ui.css":       "bc5e8d5c013477cf1f35e2fb8ba1dff66be0f72f24e669a509635657145e1acb",
+	}
+	for name, want := range pinned {
+		body, err := swaggerUI.ReadFile("swagger-ui/" + name)
+		require.NoError(t, err)
+		sum := sha256.Sum256(body)
+		assert.Equal(t, want, hex.EncodeToString(sum[:]), name)
+	}
+}
@@ -0,0 +1,207 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Nullable 을 구현한 타입(request.Optional 등)은 문서에서 NullableType 의 값 또는 null 로 보인다
+type Nullable interface {
+	NullableType() reflect.Type
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	rawJSONType  = reflect.TypeFor[json.RawMessage]()
+	nullableType = reflect.TypeFor[Nullable]()
+)
+
+// generator 는 Go 타입을 스키마로 바꾸고 이름 있는 구조체는 components 에 한 번만 넣는다
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{schemas: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// schema 는 t 의 스키마. response 면 omitempty 가 없는 필드를 required 로, 아니면 binding:"required" 인 필드만 required 로 적는다
+func (g *generator) schema(t reflect.Type, response bool) *Schema {
+	if t.Implements(nullableType) {
+		s := g.schema(reflect.Zero(t).Interface().(Nullable).NullableType(), response)
+		s.Nullable = true
+		return s
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schema(t.Elem(), response)
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: ptr(0.0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem(), response)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem(), response)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t, response)
+		}
+		return g.ref(t, response)
+	}
+	return &Schema{}
+}
+
+func (g *generator) ref(t reflect.Type, response bool) *Schema {
+	if name, ok := g.names[t]; ok {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	name := schemaName(t)
+	if _, taken := g.schemas[name]; taken {
+		// 다른 패키지의 같은 이름이면 패키지 이름을 붙인다
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	g.names[t] = name
+	// 자기 자신을 참조하는 타입이 있어도 끝나도록 먼저 자리를 잡는다
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.object(t, response)
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// object 는 구조체를 encoding/json 과 같은 규칙(json 태그, 임베드 필드 펼치기)으로 적는다
+func (g *generator) object(t reflect.Type, response bool) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || len(f.Index) > 1 && !promoted(t, f) {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			continue
+		}
+		name, omitempty := jsonName(f)
+		if name == "" {
+			continue
+		}
+		prop := g.schema(f.Type, response)
+		required := applyBinding(prop, f, t)
+		s.Properties[name] = prop
+		if required || response && !omitempty && !prop.Nullable {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// promoted 는 임베드한 구조체에서 올라온 필드가 json 에도 나오는지. 중간 구조체에 json 태그가 없어야 펼쳐진다
+func promoted(t reflect.Type, f reflect.StructField) bool {
+	for i := 1; i < len(f.Index); i++ {
+		outer := t.FieldByIndex(f.Index[:i])
+		if !outer.Anonymous || outer.Tag.Get("json") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, strings.Contains(opts, "omitempty")
+}
+
+// applyBinding 은 validator 태그를 스키마 제약으로 옮기고 required 인지 돌려준다
+// 문서로 옮길 수 없는 규칙은 설명에 남긴다
+func applyBinding(s *Schema, f reflect.StructField, parent reflect.Type) bool {
+	required := false
+	for _, rule := range strings.Split(f.Tag.Get("binding"), ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = true
+		case "email":
+			s.Format = "email"
+		case "url":
+			s.Format = "uri"
+		case "ip":
+			s.Format = "ip"
+		case "oneof":
+			s.Enum = strings.Fields(value)
+		case "min", "max":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			setBound(s, key, n)
+		case "eqfield":
+			other, _ := parent.FieldByName(value)
+			name, _ := jsonName(other)
+			s.Description = strings.TrimSpace(s.Description + " Must equal " + name + ".")
+		}
+	}
+	return required
+}
+
+func setBound(s *Schema, key string, n int) {
+	switch s.Type {
+	case "string":
+		if key == "min" {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case "integer", "number":
+		if key == "min" {
+			s.Minimum = ptr(float64(n))
+		} else {
+			s.Maximum = ptr(float64(n))
+		}
+	}
+}
+
+// schemaName 은 제네릭 타입 이름을 읽기 쉽게 바꾼다 (List[...response.AdminUser] → AdminUserList)
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	base, arg, generic := strings.Cut(name, "[")
+	if !generic {
+		return name
+	}
+	arg = strings.TrimSuffix(arg, "]")
+	return arg[strings.LastIndexAny(arg, "./")+1:] + base
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
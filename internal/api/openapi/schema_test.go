@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOptional[T any] struct{ Value T }
+
+func (testOptional[T]) NullableType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+type testSignup struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=12,max=72"`
+	Confirm  string `json:"confirm" binding:"required,eqfield=Password"`
+	Website  string `json:"website" binding:"omitempty,url"`
+	Internal string `json:"-"`
+}
+
+type testBase struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testProfile struct {
+	testBase
+	Nickname  testOptional[string] `json:"nickname"`
+	DeletedAt *time.Time           `json:"deleted_at,omitempty"`
+	Tags      []string             `json:"tags,omitempty"`
+}
+
+type testPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+func TestGenerator(t *testing.T) {
+	g := newGenerator()
+
+	t.Run("binding tags become constraints", func(t *testing.T) {
+		ref := g.schema(reflect.TypeFor[testSignup](), false)
+		s := g.schemas["testSignup"]
+
+		assert.Equal(t, "#/components/schemas/testSignup", ref.Ref)
+		require.NotNil(t, s)
+		assert.Equal(t, []string{"email", "password", "confirm"}, s.Required)
+		assert.Equal(t, "email", s.Properties["email"].Format)
+		assert.Equal(t, 12, *s.Properties["password"].MinLength)
+		assert.Equal(t, 72, *s.Properties["password"].MaxLength)
+		assert.Equal(t, "Must equal password.", s.Properties["confirm"].Description)
+		assert.Equal(t, "uri", s.Properties["website"].Format)
+		assert.NotContains(t, s.Properties, "Internal")
+	})
+
+	t.Run("responses follow encoding/json", func(t *testing.T) {
+		g.schema(reflect.TypeFor[testProfile](), true)
+		s := g.schemas["testProfile"]
+
+		require.NotNil(t, s)
+		assert.ElementsMatch(t, []string{"id", "created_at", "nickname", "deleted_at", "tags"}, slices.Collect(maps.Keys(s.Properties)))
+		assert.Equal(t, []string{"id", "created_at"}, s.Required)
+		assert.Equal(t, &Schema{Type: "string", Nullable: true}, s.Properties["nickname"])
+		assert.True(t, s.Properties["deleted_at"].Nullable)
+		assert.Equal(t, "date-time", s.Properties["deleted_at"].Format)
+	})
+
+	t.Run("generic names", func(t *testing.T) {
+		ref := g.schema(reflect.TypeFor[testPage[testProfile]](), true)
+
+		assert.Equal(t, "#/components/schemas/testProfiletestPage", ref.Ref)
+	})
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	path, params := openAPIPath("/user/avatar/:id/:name")
+
+	assert.Equal(t, "/user/avatar/{id}/{name}", path)
+	require.Len(t, params, 2)
+	assert.Equal(t, Parameter{Name: "id", In: "path", Required: true, Schema: String()}, params[0])
+}
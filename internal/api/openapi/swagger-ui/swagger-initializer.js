@@ -0,0 +1,9 @@
+// CSP 가 인라인 스크립트를 막으므로 초기화도 별도 파일로 둔다
+window.ui = SwaggerUIBundle({
+  url: "openapi.json",
+  dom_id: "#swagger-ui",
+  deepLinking: true,
+  persistAuthorization: true,
+  // 기본값은 외부 검증 서버(validator.swagger.io)로 문서를 보낸다
+  validatorUrl: null,
+});
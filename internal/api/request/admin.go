@@ -0,0 +1,25 @@
+package request
+
+import "module.resume/internal/listing"
+
+// UserSearch 는 관리자 사용자 검색이 받는 필터와 정렬
+// email 은 앞부분, name 은 일부만 맞아도 찾고, deleted=true 면 탈퇴한 계정만 본다
+var UserSearch = listing.Schema{
+	Fields: map[string]listing.Field{
+		"id":         {Kind: listing.Int, Sortable: true},
+		"email":      {Kind: listing.String, Sortable: true},
+		"name":       {Kind: listing.String, Sortable: true},
+		"created_at": {Kind: listing.Time, Sortable: true},
+		"deleted_at": {Kind: listing.Time},
+	},
+	Filters: map[string]listing.Filter{
+		"email":          {Field: "email", Op: listing.Prefix},
+		"name":           {Field: "name", Op: listing.Contains},
+		"created_after":  {Field: "created_at", Op: listing.Gte},
+		"created_before": {Field: "created_at", Op: listing.Lt},
+		"deleted":        {Field: "deleted_at", Op: listing.Present},
+	},
+	DefaultSort:  "-created_at",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
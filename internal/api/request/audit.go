@@ -0,0 +1,59 @@
+package request
+
+import (
+	"strconv"
+	"time"
+
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/audit"
+)
+
+// SecurityEvents 는 본인 보안 기록 조회의 쿼리 파라미터
+type SecurityEvents struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// AuditSearch 는 관리자 감사 기록 조회의 쿼리 파라미터. 잘못된 숫자와 시각도 422 로 돌려주려고 문자열로 받는다
+type AuditSearch struct {
+	ActorID   string `form:"actor_id"`
+	SubjectID string `form:"subject_id"`
+	Action    string `form:"action"`
+	IP        string `form:"ip" binding:"omitempty,ip"`
+	// Since 와 Until 은 RFC 3339 시각. Until 은 포함하지 않는다
+	Since string `form:"since"`
+	Until string `form:"until"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+func (s AuditSearch) ToFilter() (audit.Filter, error) {
+	filter := audit.Filter{Action: s.Action, IP: s.IP, Limit: s.Limit}
+	var fields []domain.FieldError
+	parseID := func(field, v string) uint {
+		if v == "" {
+			return 0
+		}
+		id, err := strconv.ParseUint(v, 10, 0)
+		if err != nil || id == 0 {
+			fields = append(fields, domain.FieldError{Field: field, Message: "must be a positive integer"})
+		}
+		return uint(id)
+	}
+	parseTime := func(field, v string) time.Time {
+		if v == "" {
+			return time.Time{}
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			fields = append(fields, domain.FieldError{Field: field, Message: "must be an RFC 3339 timestamp"})
+		}
+		return t
+	}
+	filter.ActorID = parseID("actor_id", s.ActorID)
+	filter.SubjectID = parseID("subject_id", s.SubjectID)
+	filter.Since = parseTime("since", s.Since)
+	filter.Until = parseTime("until", s.Until)
+	if len(fields) > 0 {
+		return audit.Filter{}, domain.Validation("request validation failed", fields...)
+	}
+	return filter, nil
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Optional 은 JSON Merge Patch 에서 필드가 빠졌는지, null 인지, 값이 있는지 구분한다
+// 필드가 빠지면 UnmarshalJSON 이 불리지 않아서 Set 이 false 로 남는다
+type Optional[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	o.Set = true
+	if string(b) == "null" {
+		o.Null = true
+		return nil
+	}
+	return json.Unmarshal(b, &o.Value)
+}
+
+// NullableType 은 API 문서에서 이 필드를 T 또는 null 로 적게 한다
+func (Optional[T]) NullableType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
@@ -1,13 +1,18 @@
 package request
 
 import (
+	"net/url"
+	"strings"
+
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
 	"module.resume/internal/domain/user"
 )
 
 type SaveUser struct {
 	Email      string `json:"email" binding:"required,email"`
 	Name       string `json:"name" binding:"required"`
-	Password   string `json:"password" binding:"required,min=12"`
+	Password   string `json:"password" binding:"required,min=12,max=72"`
 	ProfileUrl string `json:"profile_url" binding:"url"`
 }
 
@@ -19,19 +24,73 @@ func (s SaveUser) ToDomain() (*user.User, error) {
 	return domain, nil
 }
 
+// UpdateUser 는 프로필 전체를 바꾼다. 빠진 필드는 빈 값으로 저장되므로 일부만 바꿀 때는 PatchUser 를 쓴다
+// 대상은 토큰의 사용자이고, email 은 ChangeEmail 로만 바꿀 수 있다
 type UpdateUser struct {
-	ID         uint   `json:"id" binding:"required"`
-	Email      string `json:"email" binding:"email"`
-	Name       string `json:"name"`
-	ProfileUrl string `json:"profile_url" binding:"url"`
+	Name       string `json:"name" binding:"required"`
+	ProfileUrl string `json:"profile_url" binding:"omitempty,url"`
 }
 
-func (u UpdateUser) ToDomain() *user.User {
-	return user.NewUserForUpdate(u.ID, u.Email, u.Name, u.ProfileUrl)
+func (u UpdateUser) ToPatch() application.ProfilePatch {
+	return application.ProfilePatch{Name: &u.Name, ProfileUrl: &u.ProfileUrl}
 }
 
 type UpdateUserPassword struct {
 	CurrentPassword string `json:"currentPassword" binding:"required"`
-	NewPassword     string `json:"newPassword" binding:"required,min=8"`
+	NewPassword     string `json:"newPassword" binding:"required,min=12,max=72"`
 	ConfirmPassword string `json:"confirmPassword" binding:"required,eqfield=NewPassword"`
 }
+
+// ChangeEmail 은 email 변경 요청. 새 주소로 받은 토큰으로 확인해야 바뀐다
+type ChangeEmail struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// EmailChangeToken 은 메일로 받은 확인/되돌리기 토큰
+type EmailChangeToken struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type DeleteUser struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type CancelUserDeletion struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// PatchUser 는 JSON Merge Patch (RFC 7396) 본문. 빠진 필드는 그대로 두고 null 은 값을 지운다
+type PatchUser struct {
+	Name       Optional[string] `json:"name"`
+	ProfileUrl Optional[string] `json:"profile_url"`
+}
+
+func (p PatchUser) Validate() error {
+	var fields []domain.FieldError
+	if p.Name.Set && (p.Name.Null || strings.TrimSpace(p.Name.Value) == "") {
+		fields = append(fields, domain.FieldError{Field: "name", Message: "must not be empty"})
+	}
+	if p.ProfileUrl.Set && !p.ProfileUrl.Null {
+		if u, err := url.Parse(p.ProfileUrl.Value); err != nil || u.Scheme == "" || u.Host == "" {
+			fields = append(fields, domain.FieldError{Field: "profile_url", Message: "must be a valid URL"})
+		}
+	}
+	if len(fields) > 0 {
+		return domain.Validation("request validation failed", fields...)
+	}
+	return nil
+}
+
+func (p PatchUser) ToPatch() application.ProfilePatch {
+	var patch application.ProfilePatch
+	if p.Name.Set {
+		patch.Name = &p.Name.Value
+	}
+	if p.ProfileUrl.Set {
+		// null 이면 Value 가 빈 문자열이라서 프로필 URL 이 지워진다
+		patch.ProfileUrl = &p.ProfileUrl.Value
+	}
+	return patch
+}
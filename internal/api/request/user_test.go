@@ -0,0 +1,46 @@
+package request
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+)
+
+func TestPatchUser(t *testing.T) {
+	decode := func(t *testing.T, body string) PatchUser {
+		var p PatchUser
+		require.NoError(t, json.Unmarshal([]byte(body), &p))
+		return p
+	}
+
+	t.Run("omitted, null and value are distinguished", func(t *testing.T) {
+		p := decode(t, `{"profile_url": null}`)
+
+		assert.NoError(t, p.Validate())
+		patch := p.ToPatch()
+		assert.Nil(t, patch.Name)
+		require.NotNil(t, patch.ProfileUrl)
+		assert.Equal(t, "", *patch.ProfileUrl)
+
+		patch = decode(t, `{"name": "New"}`).ToPatch()
+		require.NotNil(t, patch.Name)
+		assert.Equal(t, "New", *patch.Name)
+		assert.Nil(t, patch.ProfileUrl)
+	})
+
+	t.Run("name cannot be removed", func(t *testing.T) {
+		for _, body := range []string{`{"name": null}`, `{"name": "  "}`} {
+			err := decode(t, body).Validate()
+
+			assert.ErrorIs(t, err, domain.ErrValidation, body)
+		}
+	})
+
+	t.Run("profile url must be a url", func(t *testing.T) {
+		assert.ErrorIs(t, decode(t, `{"profile_url": "not a url"}`).Validate(), domain.ErrValidation)
+		assert.NoError(t, decode(t, `{"profile_url": "https://example.com/me.png"}`).Validate())
+	})
+}
@@ -0,0 +1,59 @@
+package response
+
+import (
+	"time"
+
+	"module.resume/internal/domain/audit"
+)
+
+// SecurityEvent 는 사용자 본인에게 보여주는 보안 기록
+type SecurityEvent struct {
+	Action     string    `json:"action"`
+	OccurredAt time.Time `json:"occurred_at"`
+	IP         string    `json:"ip,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+}
+
+func FromSecurityEvents(entries []audit.Entry) []SecurityEvent {
+	events := make([]SecurityEvent, 0, len(entries))
+	for _, e := range entries {
+		events = append(events, SecurityEvent{
+			Action:     e.Action,
+			OccurredAt: e.OccurredAt,
+			IP:         e.IP,
+			UserAgent:  e.UserAgent,
+		})
+	}
+	return events
+}
+
+// AuditEvent 는 관리자 조회용으로 기록을 모두 보여준다
+type AuditEvent struct {
+	ID         int64             `json:"id"`
+	Action     string            `json:"action"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	ActorID    uint              `json:"actor_id,omitempty"`
+	SubjectID  uint              `json:"subject_id,omitempty"`
+	IP         string            `json:"ip,omitempty"`
+	UserAgent  string            `json:"user_agent,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+func FromAuditEvents(entries []audit.Entry) []AuditEvent {
+	events := make([]AuditEvent, 0, len(entries))
+	for _, e := range entries {
+		events = append(events, AuditEvent{
+			ID:         e.ID,
+			Action:     e.Action,
+			OccurredAt: e.OccurredAt,
+			ActorID:    e.ActorID,
+			SubjectID:  e.SubjectID,
+			IP:         e.IP,
+			UserAgent:  e.UserAgent,
+			RequestID:  e.RequestID,
+			Metadata:   e.Metadata,
+		})
+	}
+	return events
+}
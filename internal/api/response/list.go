@@ -0,0 +1,8 @@
+package response
+
+// List 는 커서로 나눈 목록 응답. 다음 페이지 주소는 Link 헤더에도 담는다
+type List[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor 를 cursor 파라미터로 넘기면 다음 페이지. 마지막 페이지면 없다
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,40 @@
+package response
+
+import (
+	"time"
+
+	"module.resume/internal/domain/user"
+)
+
+// User 는 사용자 본인에게 보여주는 프로필. 비밀번호 해시 같은 내부 값은 넣지 않는다
+type User struct {
+	ID         uint   `json:"id"`
+	Email      string `json:"email"`
+	Name       string `json:"name"`
+	ProfileUrl string `json:"profile_url"`
+	// AvatarUrl 은 업로드한 프로필 사진의 서명된 링크. 잠시 뒤 만료된다
+	AvatarUrl string    `json:"avatar_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func FromUser(u *user.User) User {
+	return User{
+		ID:         u.ID,
+		Email:      u.Email,
+		Name:       u.Name,
+		ProfileUrl: u.ProfileUrl,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+	}
+}
+
+// AdminUser 는 관리자 검색 결과. 탈퇴한 계정이면 탈퇴 시각이 있다
+type AdminUser struct {
+	User
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+func FromAdminUser(u *user.User) AdminUser {
+	return AdminUser{User: FromUser(u), DeletedAt: u.DeletedAt}
+}
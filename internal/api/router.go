@@ -1,34 +1,160 @@
 package api
 
 import (
-	"time"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"module.resume/internal/api/handler"
 	"module.resume/internal/api/middleware"
+	"module.resume/internal/api/openapi"
+	"module.resume/internal/api/request"
+	"module.resume/internal/api/response"
 )
 
-func MakeRouter(handlers *handler.Handlers, authMiddleware gin.HandlerFunc) *gin.Engine {
-	r := gin.Default()
+// trustedProxies 가 비어 있으면 X-Forwarded-For 를 무시하고 접속한 주소를 클라이언트 IP 로 쓴다
+// 라우트는 문서(openapi.Route)와 함께 등록하고 /openapi.json 은 등록한 라우트로 만든다
+func MakeRouter(handlers *handler.Handlers, middlewares *middleware.Middlewares, trustedProxies []string) (*gin.Engine, error) {
+	registerValidation()
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+	r.Use(middleware.RequestIDMiddleware())
+	r.Use(middleware.RequestInfoMiddleware())
+	r.Use(middleware.LoggerMiddleware())
+	r.Use(middleware.RecoveryMiddleware())
+	r.Use(middlewares.Metrics)
+	r.Use(middlewares.Tracing)
+	// 메트릭과 트레이스가 변환된 상태 코드를 보도록 에러 변환은 그 안쪽에 둔다
+	r.Use(middleware.ErrorMiddleware())
+
+	routes := &apiRoutes{}
+	root := &r.RouterGroup
+	routes.handle(root, openapi.Route{Method: http.MethodGet, Path: "/healthz", Tag: "system", Summary: "Liveness probe",
+		Status: http.StatusOK, Response: openapi.Object(map[string]*openapi.Schema{"status": openapi.String()})},
+		handlers.Health.Live)
+	routes.handle(root, openapi.Route{Method: http.MethodGet, Path: "/readyz", Tag: "system", Summary: "Readiness probe. Returns 503 while draining or when a dependency is down",
+		Status: http.StatusOK, Response: openapi.Object(map[string]*openapi.Schema{
+			"status": openapi.String(),
+			"checks": {Type: "object", AdditionalProperties: openapi.Object(map[string]*openapi.Schema{
+				"status":     openapi.String(),
+				"latency_ms": {Type: "number"},
+				"error":      openapi.String(),
+			})},
+		})},
+		handlers.Health.Ready)
+	routes.handle(root, openapi.Route{Method: http.MethodGet, Path: "/metrics", Tag: "system", Summary: "Prometheus metrics",
+		Status: http.StatusOK, Produces: "text/plain"},
+		gin.WrapH(handlers.Metrics))
+	routes.handle(root, openapi.Route{Method: http.MethodGet, Path: "/openapi.json", Tag: "system", Summary: "This document",
+		Status: http.StatusOK, Response: &openapi.Schema{Type: "object"}},
+		openapi.SpecHandler(routes.spec))
+	routes.handle(root, openapi.Route{Method: http.MethodGet, Path: "/docs", Tag: "system", Summary: "Swagger UI for /openapi.json",
+		Status: http.StatusOK, Produces: "text/html"},
+		openapi.UIHandler())
+	routes.handle(root, openapi.Route{Method: http.MethodGet, Path: "/docs/:file", Tag: "system", Summary: "Swagger UI scripts and stylesheet",
+		Status: http.StatusOK, Produces: "*/*", Errors: []int{http.StatusNotFound}},
+		openapi.UIAssetHandler())
+
 	r.Use(middleware.TokenExtractorMiddleware())
-	r.Use(middleware.TimeoutMiddleware(10 * time.Second))
+	r.Use(middlewares.Timeout)
 
 	user := r.Group("/user")
 	{
-		user.POST("/", handlers.User.Save)
-		user.DELETE("/:id", handlers.User.Delete)
+		routes.handle(user, openapi.Route{Method: http.MethodPost, Path: "/", Tag: "user", Summary: "Sign up",
+			Body: request.SaveUser{}, Status: http.StatusCreated, Response: uint(0),
+			Errors: []int{http.StatusConflict}},
+			handlers.User.Save)
+		// 탈퇴하면 토큰이 모두 무효가 되므로 취소는 email 과 비밀번호로 한다
+		routes.handle(user, openapi.Route{Method: http.MethodPost, Path: "/restore", Tag: "user", Summary: "Cancel account deletion within the grace period",
+			Body: request.CancelUserDeletion{}, Status: http.StatusNoContent,
+			Errors: []int{http.StatusUnauthorized, http.StatusConflict}},
+			handlers.User.CancelDeletion)
+		// 메일로 보낸 링크라서 토큰 대신 서명으로 확인한다
+		routes.handle(user, openapi.Route{Method: http.MethodGet, Path: "/export/:id/download", Tag: "user", Summary: "Download a data export from the emailed link",
+			Query: signedLink{}, Status: http.StatusOK, Produces: "application/zip",
+			Errors: []int{http.StatusForbidden, http.StatusNotFound}},
+			handlers.Export.Download)
+		// 확인/되돌리기 토큰은 메일로 받으므로 로그인 없이 쓸 수 있다
+		routes.handle(user, openapi.Route{Method: http.MethodPost, Path: "/email/confirm", Tag: "user", Summary: "Confirm an email change with the token sent to the new address",
+			Body: request.EmailChangeToken{}, Status: http.StatusNoContent,
+			Errors: []int{http.StatusNotFound, http.StatusConflict}},
+			handlers.Email.Confirm)
+		routes.handle(user, openapi.Route{Method: http.MethodPost, Path: "/email/undo", Tag: "user", Summary: "Undo an email change with the token sent to the old address",
+			Body: request.EmailChangeToken{}, Status: http.StatusNoContent,
+			Errors: []int{http.StatusNotFound, http.StatusConflict}},
+			handlers.Email.Undo)
+		routes.handle(user, openapi.Route{Method: http.MethodGet, Path: "/avatar/:id/:name", Tag: "user", Summary: "Download a profile photo from a signed avatar_url",
+			Query: signedLink{}, Status: http.StatusOK, Produces: "image/*",
+			Errors: []int{http.StatusForbidden, http.StatusNotFound}},
+			handlers.Avatar.Download)
 		me := user.Group("/me")
 		{
-			me.Use(authMiddleware)
-			me.PUT("/", handlers.User.Update)
-			me.PUT("/password", handlers.User.UpdatePassword)
+			me.Use(middlewares.Auth)
+			routes.handle(me, openapi.Route{Method: http.MethodGet, Path: "/", Tag: "user", Summary: "Get the caller's profile", Auth: true,
+				Status: http.StatusOK, Response: response.User{},
+				Errors: []int{http.StatusNotFound}},
+				handlers.User.Me)
+			routes.handle(me, openapi.Route{Method: http.MethodPut, Path: "/", Tag: "user", Summary: "Replace the caller's profile", Auth: true,
+				Body: request.UpdateUser{}, Status: http.StatusAccepted, Response: uint(0),
+				Errors: []int{http.StatusNotFound}},
+				handlers.User.Update)
+			routes.handle(me, openapi.Route{Method: http.MethodPatch, Path: "/", Tag: "user", Summary: "Update profile fields with a JSON Merge Patch", Auth: true,
+				Body: request.PatchUser{}, Status: http.StatusOK, Response: response.User{},
+				Errors: []int{http.StatusNotFound}},
+				handlers.User.Patch)
+			routes.handle(me, openapi.Route{Method: http.MethodPut, Path: "/password", Tag: "user", Summary: "Change password and revoke all tokens", Auth: true,
+				Body: request.UpdateUserPassword{}, Status: http.StatusNoContent},
+				handlers.User.UpdatePassword)
+			routes.handle(me, openapi.Route{Method: http.MethodPost, Path: "/email", Tag: "user", Summary: "Request an email change", Auth: true,
+				Body: request.ChangeEmail{}, Status: http.StatusAccepted,
+				Errors: []int{http.StatusConflict}},
+				handlers.Email.Request)
+			routes.handle(me, openapi.Route{Method: http.MethodPost, Path: "/avatar", Tag: "user", Summary: "Upload a profile photo (JPEG, PNG or GIF)", Auth: true,
+				Upload: "avatar", Status: http.StatusOK, Response: response.User{}},
+				handlers.Avatar.Upload)
+			routes.handle(me, openapi.Route{Method: http.MethodDelete, Path: "/avatar", Tag: "user", Summary: "Remove the profile photo", Auth: true,
+				Status: http.StatusNoContent},
+				handlers.Avatar.Remove)
+			routes.handle(me, openapi.Route{Method: http.MethodDelete, Path: "/", Tag: "user", Summary: "Delete the caller's account after a grace period", Auth: true,
+				Body: request.DeleteUser{}, Status: http.StatusNoContent},
+				handlers.User.Delete)
+			routes.handle(me, openapi.Route{Method: http.MethodPost, Path: "/export", Tag: "user", Summary: "Start a personal data export; the link is emailed", Auth: true,
+				Status: http.StatusAccepted, Response: openapi.Object(map[string]*openapi.Schema{
+					"id":     openapi.Integer(),
+					"status": openapi.String(),
+				})},
+				handlers.Export.Request)
+			routes.handle(me, openapi.Route{Method: http.MethodGet, Path: "/security-events", Tag: "user", Summary: "Recent security events on the caller's account", Auth: true,
+				Query: request.SecurityEvents{}, Status: http.StatusOK, Response: []response.SecurityEvent{}},
+				handlers.Audit.Mine)
 		}
 	}
 
+	admin := r.Group("/admin")
+	{
+		admin.Use(middlewares.Auth, middlewares.Admin)
+		routes.handle(admin, openapi.Route{Method: http.MethodGet, Path: "/audit-events", Tag: "admin", Summary: "Search the audit log", Auth: true,
+			Query: request.AuditSearch{}, Status: http.StatusOK, Response: []response.AuditEvent{},
+			Errors: []int{http.StatusForbidden}},
+			handlers.Audit.Search)
+		routes.handle(admin, openapi.Route{Method: http.MethodGet, Path: "/users", Tag: "admin", Summary: "Search users, including deleted accounts", Auth: true,
+			Listing: &request.UserSearch, Status: http.StatusOK, Response: response.List[response.AdminUser]{},
+			Errors: []int{http.StatusForbidden}},
+			handlers.Admin.Users)
+	}
+
 	{
-		r.POST("/login", handlers.Auth.Login)
-		r.POST("/logout", handlers.Auth.Logout)
+		routes.handle(root, openapi.Route{Method: http.MethodPost, Path: "/login", Tag: "auth", Summary: "Log in and get an access token",
+			Body: request.LoginRequest{}, Status: http.StatusOK,
+			Response: openapi.Object(map[string]*openapi.Schema{"accessToken": openapi.String()}),
+			Errors:   []int{http.StatusUnauthorized}},
+			handlers.Auth.Login)
+		routes.handle(root, openapi.Route{Method: http.MethodPost, Path: "/logout", Tag: "auth", Summary: "Revoke the current access token", Auth: true,
+			Status: http.StatusOK, Response: openapi.Object(map[string]*openapi.Schema{"message": openapi.String()})},
+			handlers.Auth.Logout)
 	}
 
-	return r
+	return r, nil
 }
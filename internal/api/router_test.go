@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/api/handler"
+	"module.resume/internal/api/middleware"
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+)
+
+// 문서 라우트만 호출하므로 다른 핸들러는 비어 있어도 된다
+func testRouter(trustedProxies ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	next := func(c *gin.Context) { c.Next() }
+	r, err := MakeRouter(&handler.Handlers{}, &middleware.Middlewares{
+		Auth: next, Admin: next, Timeout: next, Metrics: next, Tracing: next,
+	}, trustedProxies)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestMakeRouter_TrustedProxies(t *testing.T) {
+	clientIP := func(r *gin.Engine) string {
+		var ip string
+		r.GET("/ip", func(c *gin.Context) { ip = application.RequestInfoFrom(c.Request.Context()).IP })
+		req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+		req.RemoteAddr = "192.0.2.10:4321"
+		req.Header.Set("X-Forwarded-For", "198.51.100.7")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		return ip
+	}
+
+	// 기본값은 아무 프록시도 믿지 않아서 감사 기록의 IP 를 헤더로 바꿀 수 없다
+	assert.Equal(t, "192.0.2.10", clientIP(testRouter()))
+	assert.Equal(t, "198.51.100.7", clientIP(testRouter("192.0.2.0/24")))
+}
+
+type statusRecorder struct {
+	statuses map[string]int
+}
+
+func (r *statusRecorder) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	r.statuses[method+" "+route] = status
+}
+
+func TestMakeRouter_MetricsSeeErrorStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := &statusRecorder{statuses: map[string]int{}}
+	next := func(c *gin.Context) { c.Next() }
+	r, err := MakeRouter(&handler.Handlers{}, &middleware.Middlewares{
+		Auth: next, Admin: next, Timeout: next, Tracing: next,
+		Metrics: middleware.MetricsMiddleware(recorder),
+	}, nil)
+	require.NoError(t, err)
+	r.GET("/missing-user", func(c *gin.Context) { c.Error(domain.NotFound("user not found")) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing-user", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, http.StatusNotFound, recorder.statuses["GET /missing-user"])
+}
+
+func TestSpecCoversRoutes(t *testing.T) {
+	r := testRouter()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	registered := map[string]bool{}
+
+	for _, route := range r.Routes() {
+		segments := strings.Split(route.Path, "/")
+		for i, s := range segments {
+			if name, ok := strings.CutPrefix(s, ":"); ok {
+				segments[i] = "{" + name + "}"
+			}
+		}
+		path := strings.Join(segments, "/")
+		method := strings.ToLower(route.Method)
+		registered[method+" "+path] = true
+
+		assert.Contains(t, spec.Paths[path], method, "%s %s is not in the OpenAPI spec", route.Method, route.Path)
+	}
+	// 문서에 그룹 경로가 잘못 붙으면 등록한 라우트와 어긋난다
+	for path, item := range spec.Paths {
+		for method := range item {
+			assert.True(t, registered[method+" "+path], "%s %s is documented but not routed", method, path)
+		}
+	}
+}
+
+func TestSpecHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	testRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+}
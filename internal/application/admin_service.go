@@ -0,0 +1,37 @@
+package application
+
+import (
+	"context"
+
+	"module.resume/internal/domain/audit"
+	"module.resume/internal/domain/user"
+	"module.resume/internal/listing"
+)
+
+// UserSearcher 는 관리자 사용자 검색. 탈퇴한 계정도 찾아야 하므로 캐시를 거치지 않는 저장소가 구현한다
+type UserSearcher interface {
+	Search(ctx context.Context, spec listing.Spec) (listing.Page[*user.User], error)
+}
+
+// AdminService 는 관리자 API. 조회한 사실도 감사 기록에 남긴다
+type AdminService interface {
+	SearchUsers(ctx context.Context, spec listing.Spec) (listing.Page[*user.User], error)
+}
+
+type adminService struct {
+	users  UserSearcher
+	audits AuditLog
+}
+
+func NewAdminService(users UserSearcher, audits AuditLog) AdminService {
+	return &adminService{users: users, audits: audits}
+}
+
+func (s *adminService) SearchUsers(ctx context.Context, spec listing.Spec) (listing.Page[*user.User], error) {
+	page, err := s.users.Search(ctx, spec)
+	if err != nil {
+		return listing.Page[*user.User]{}, err
+	}
+	s.audits.Record(ctx, audit.ActionAdminUsersSearched, 0, map[string]string{"query": spec.Query()})
+	return page, nil
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"module.resume/internal/domain/audit"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+// RequestInfo 는 감사 기록에 남길 요청 정보. API 미들웨어가 ctx 에 넣는다
+type RequestInfo struct {
+	// ActorID 는 토큰으로 확인된 사용자. 로그인 전이면 0
+	ActorID   uint
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+type requestInfoKey struct{}
+
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+func RequestInfoFrom(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
+
+// WithActor 는 인증이 끝난 뒤 ctx 의 RequestInfo 에 사용자를 채운다
+func WithActor(ctx context.Context, userID uint) context.Context {
+	info := RequestInfoFrom(ctx)
+	info.ActorID = userID
+	return WithRequestInfo(ctx, info)
+}
+
+// AuditLog 는 보안 관련 계정 활동을 기록하고 조회한다
+type AuditLog interface {
+	// Record 는 기록에 실패해도 원래 작업을 실패시키지 않고 로그만 남긴다
+	Record(ctx context.Context, action string, subjectID uint, metadata map[string]string)
+	// ForUser 는 사용자 본인 계정에 대한 최근 기록
+	ForUser(ctx context.Context, userID uint, limit int) ([]audit.Entry, error)
+	// Search 는 관리자용 조회. 조회한 사실도 기록한다
+	Search(ctx context.Context, filter audit.Filter) ([]audit.Entry, error)
+}
+
+type auditLog struct {
+	repo audit.Repository
+	log  *slog.Logger
+}
+
+func NewAuditLog(repo audit.Repository, log *slog.Logger) AuditLog {
+	return &auditLog{repo: repo, log: log}
+}
+
+func (a *auditLog) Record(ctx context.Context, action string, subjectID uint, metadata map[string]string) {
+	info := RequestInfoFrom(ctx)
+	entry := &audit.Entry{
+		OccurredAt: time.Now(),
+		Action:     action,
+		ActorID:    info.ActorID,
+		SubjectID:  subjectID,
+		IP:         info.IP,
+		UserAgent:  info.UserAgent,
+		RequestID:  info.RequestID,
+		Metadata:   metadata,
+	}
+	// 요청이 끝나거나 타임아웃이 나도 기록은 남긴다
+	if err := a.repo.Append(context.WithoutCancel(ctx), entry); err != nil {
+		a.log.ErrorContext(ctx, "Failed to record audit entry", "action", action, "subject_id", subjectID, "error", err)
+	}
+}
+
+func (a *auditLog) ForUser(ctx context.Context, userID uint, limit int) ([]audit.Entry, error) {
+	return a.repo.Search(ctx, audit.Filter{SubjectID: userID, Limit: auditLimit(limit)})
+}
+
+func (a *auditLog) Search(ctx context.Context, filter audit.Filter) ([]audit.Entry, error) {
+	filter.Limit = auditLimit(filter.Limit)
+	entries, err := a.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	a.Record(ctx, audit.ActionAdminAuditSearched, filter.SubjectID, filterMetadata(filter))
+	return entries, nil
+}
+
+func auditLimit(limit int) int {
+	if limit <= 0 {
+		return defaultAuditLimit
+	}
+	return min(limit, maxAuditLimit)
+}
+
+func filterMetadata(f audit.Filter) map[string]string {
+	m := map[string]string{}
+	if f.ActorID != 0 {
+		m["actor_id"] = strconv.FormatUint(uint64(f.ActorID), 10)
+	}
+	if f.Action != "" {
+		m["action"] = f.Action
+	}
+	if f.IP != "" {
+		m["ip"] = f.IP
+	}
+	if !f.Since.IsZero() {
+		m["since"] = f.Since.Format(time.RFC3339)
+	}
+	if !f.Until.IsZero() {
+		m["until"] = f.Until.Format(time.RFC3339)
+	}
+	return m
+}
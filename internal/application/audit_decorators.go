@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/audit"
+	"module.resume/internal/domain/user"
+)
+
+// 아래 데코레이터들은 서비스를 건드리지 않고 결과만 보고 감사 기록을 남긴다
+
+type auditedAuthService struct {
+	AuthService
+	users user.Repository
+	log   AuditLog
+}
+
+func AuditAuthService(service AuthService, users user.Repository, log AuditLog) AuthService {
+	return &auditedAuthService{AuthService: service, users: users, log: log}
+}
+
+// Login 은 실패해도 어느 계정을 노렸는지 남긴다. 없는 계정이면 subject 없이 시도한 email 만 남긴다
+func (s *auditedAuthService) Login(ctx context.Context, u *user.User) (string, error) {
+	token, err := s.AuthService.Login(ctx, u)
+	if err != nil && !errors.Is(err, domain.ErrUnauthorized) {
+		return "", err
+	}
+	var subjectID uint
+	if stored, findErr := s.users.FindByEmail(ctx, u.Email); findErr == nil {
+		subjectID = stored.ID
+	}
+	if err != nil {
+		s.log.Record(ctx, audit.ActionLoginFailed, subjectID, map[string]string{"email": u.Email})
+		return "", err
+	}
+	s.log.Record(WithActor(ctx, subjectID), audit.ActionLoginSucceeded, subjectID, nil)
+	return token, nil
+}
+
+// Logout 은 유효한 토큰으로 로그아웃한 경우만 남긴다
+func (s *auditedAuthService) Logout(ctx context.Context, token string) error {
+	claims, authErr := s.AuthService.Authenticate(ctx, token)
+	if err := s.AuthService.Logout(ctx, token); err != nil {
+		return err
+	}
+	if authErr == nil {
+		s.log.Record(WithActor(ctx, claims.UserID), audit.ActionLogout, claims.UserID, nil)
+	}
+	return nil
+}
+
+type auditedUserService struct {
+	UserService
+	users user.Repository
+	log   AuditLog
+}
+
+func AuditUserService(service UserService, users user.Repository, log AuditLog) UserService {
+	return &auditedUserService{UserService: service, users: users, log: log}
+}
+
+// UpdatePassword 와 Delete 는 로그인한 본인만 호출하므로 subject 는 actor 와 같다
+func (s *auditedUserService) UpdatePassword(ctx context.Context, userID uint, currentPassword, newPassword string) error {
+	err := s.UserService.UpdatePassword(ctx, userID, currentPassword, newPassword)
+	subjectID := RequestInfoFrom(ctx).ActorID
+	switch {
+	case err == nil:
+		s.log.Record(ctx, audit.ActionPasswordChanged, subjectID, nil)
+		s.log.Record(ctx, audit.ActionTokensRevoked, subjectID, map[string]string{"reason": audit.ActionPasswordChanged})
+	case errors.Is(err, domain.ErrUnauthorized):
+		s.log.Record(ctx, audit.ActionPasswordChangeFailed, subjectID, nil)
+	}
+	return err
+}
+
+func (s *auditedUserService) Delete(ctx context.Context, userID uint, password string) error {
+	if err := s.UserService.Delete(ctx, userID, password); err != nil {
+		return err
+	}
+	subjectID := RequestInfoFrom(ctx).ActorID
+	s.log.Record(ctx, audit.ActionAccountDeleted, subjectID, nil)
+	s.log.Record(ctx, audit.ActionTokensRevoked, subjectID, map[string]string{"reason": audit.ActionAccountDeleted})
+	return nil
+}
+
+// CancelDeletion 은 토큰 없이 호출하므로 복구된 계정을 다시 찾아 subject 로 쓴다
+func (s *auditedUserService) CancelDeletion(ctx context.Context, email, password string) error {
+	if err := s.UserService.CancelDeletion(ctx, email, password); err != nil {
+		return err
+	}
+	var subjectID uint
+	if stored, err := s.users.FindByEmail(ctx, email); err == nil {
+		subjectID = stored.ID
+	}
+	s.log.Record(WithActor(ctx, subjectID), audit.ActionDeletionCancelled, subjectID, nil)
+	return nil
+}
+
+type auditedEmailChangeService struct {
+	EmailChangeService
+	log AuditLog
+}
+
+func AuditEmailChangeService(service EmailChangeService, log AuditLog) EmailChangeService {
+	return &auditedEmailChangeService{EmailChangeService: service, log: log}
+}
+
+func (s *auditedEmailChangeService) Request(ctx context.Context, userID uint, newEmail, password string) error {
+	if err := s.EmailChangeService.Request(ctx, userID, newEmail, password); err != nil {
+		return err
+	}
+	s.log.Record(ctx, audit.ActionEmailChangeRequested, userID, map[string]string{"new_email": newEmail})
+	return nil
+}
+
+// Confirm 과 Undo 는 메일 링크로 호출하므로 actor 가 없다
+func (s *auditedEmailChangeService) Confirm(ctx context.Context, token string) (*user.EmailChange, error) {
+	change, err := s.EmailChangeService.Confirm(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Record(ctx, audit.ActionEmailChanged, change.UserID, emailChangeMetadata(change))
+	s.log.Record(ctx, audit.ActionTokensRevoked, change.UserID, map[string]string{"reason": audit.ActionEmailChanged})
+	return change, nil
+}
+
+func (s *auditedEmailChangeService) Undo(ctx context.Context, token string) (*user.EmailChange, error) {
+	change, err := s.EmailChangeService.Undo(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Record(ctx, audit.ActionEmailChangeUndone, change.UserID, emailChangeMetadata(change))
+	// 확인 전에 취소한 경우는 주소도 토큰도 그대로다
+	if change.Status == user.EmailChangeUndone {
+		s.log.Record(ctx, audit.ActionTokensRevoked, change.UserID, map[string]string{"reason": audit.ActionEmailChangeUndone})
+	}
+	return change, nil
+}
+
+func emailChangeMetadata(c *user.EmailChange) map[string]string {
+	return map[string]string{"old_email": c.OldEmail, "new_email": c.NewEmail}
+}
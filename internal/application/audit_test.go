@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/audit"
+	"module.resume/internal/domain/user"
+)
+
+type fakeAuditRepository struct {
+	entries []audit.Entry
+	filter  audit.Filter
+}
+
+func (f *fakeAuditRepository) Append(ctx context.Context, e *audit.Entry) error {
+	e.ID = int64(len(f.entries) + 1)
+	f.entries = append(f.entries, *e)
+	return nil
+}
+
+func (f *fakeAuditRepository) Search(ctx context.Context, filter audit.Filter) ([]audit.Entry, error) {
+	f.filter = filter
+	return f.entries, nil
+}
+
+func (f *fakeAuditRepository) actions() []string {
+	actions := make([]string, 0, len(f.entries))
+	for _, e := range f.entries {
+		actions = append(actions, e.Action)
+	}
+	return actions
+}
+
+func newTestAuditLog() (AuditLog, *fakeAuditRepository) {
+	repo := &fakeAuditRepository{}
+	return NewAuditLog(repo, slog.New(slog.NewTextHandler(io.Discard, nil))), repo
+}
+
+func TestAuditAuthService_Login(t *testing.T) {
+	email, password := "test@example.com", "correct-password"
+	ctx := WithRequestInfo(context.Background(), RequestInfo{IP: "203.0.113.7", UserAgent: "test-agent", RequestID: "req-1"})
+
+	users := new(MockUserRepository)
+	stored := newStoredUser(t, email, password)
+	users.On("FindCredentials", ctx, email).Return(stored, nil)
+	users.On("FindByEmail", ctx, email).Return(stored, nil)
+	log, repo := newTestAuditLog()
+	service := AuditAuthService(NewAuthService(users, new(MockCache), testSecret, time.Hour), users, log)
+
+	_, err := service.Login(ctx, user.NewUserForLogin(email, "wrong-password"))
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	_, err = service.Login(ctx, user.NewUserForLogin(email, password))
+	require.NoError(t, err)
+
+	require.Len(t, repo.entries, 2)
+	failed, succeeded := repo.entries[0], repo.entries[1]
+	assert.Equal(t, audit.ActionLoginFailed, failed.Action)
+	assert.Equal(t, stored.ID, failed.SubjectID)
+	assert.Zero(t, failed.ActorID)
+	assert.Equal(t, map[string]string{"email": email}, failed.Metadata)
+	assert.Equal(t, audit.ActionLoginSucceeded, succeeded.Action)
+	assert.Equal(t, stored.ID, succeeded.ActorID)
+	assert.Equal(t, "203.0.113.7", succeeded.IP)
+	assert.Equal(t, "test-agent", succeeded.UserAgent)
+	assert.Equal(t, "req-1", succeeded.RequestID)
+}
+
+func TestAuditUserService_UpdatePassword(t *testing.T) {
+	email, password := "test@example.com", "current-password"
+	ctx := WithActor(context.Background(), 1)
+
+	users, outbox := new(MockUserRepository), new(MockOutbox)
+	outbox.On("Append", mock.Anything, mock.Anything).Return(nil)
+	users.On("FindByID", mock.Anything, uint(1)).Return(newStoredUser(t, email, password), nil)
+	users.On("UpdatePassword", mock.Anything, mock.Anything).Return(nil)
+	log, repo := newTestAuditLog()
+	service := AuditUserService(NewUserService(users, fakeTxManager{}, outbox, time.Hour), users, log)
+
+	assert.ErrorIs(t, service.UpdatePassword(ctx, 1, "wrong-password", "new-password-123"), domain.ErrUnauthorized)
+	require.NoError(t, service.UpdatePassword(ctx, 1, password, "new-password-123"))
+
+	assert.Equal(t, []string{
+		audit.ActionPasswordChangeFailed,
+		audit.ActionPasswordChanged,
+		audit.ActionTokensRevoked,
+	}, repo.actions())
+	for _, e := range repo.entries {
+		assert.Equal(t, uint(1), e.SubjectID)
+	}
+}
+
+func TestAuditLog_Search(t *testing.T) {
+	ctx := WithActor(context.Background(), 9)
+	log, repo := newTestAuditLog()
+
+	_, err := log.Search(ctx, audit.Filter{SubjectID: 1, Action: audit.ActionLoginFailed, Limit: 1000})
+	require.NoError(t, err)
+
+	assert.Equal(t, maxAuditLimit, repo.filter.Limit)
+	// 관리자가 조회한 사실도 남는다
+	require.Len(t, repo.entries, 1)
+	assert.Equal(t, audit.ActionAdminAuditSearched, repo.entries[0].Action)
+	assert.Equal(t, uint(9), repo.entries[0].ActorID)
+	assert.Equal(t, map[string]string{"action": audit.ActionLoginFailed}, repo.entries[0].Metadata)
+}
@@ -6,12 +6,26 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel"
 	"module.resume/internal/auth"
+	"module.resume/internal/domain"
 	"module.resume/internal/domain/user"
+	"module.resume/internal/util"
 )
 
+var (
+	ErrTokenBlocklisted = domain.Unauthorized("token is blocklisted")
+	ErrTokenRevoked     = domain.Unauthorized("token has been revoked")
+)
+
+// 가입 여부가 드러나지 않도록 없는 이메일과 틀린 비밀번호를 같은 에러로 돌려준다
+var errInvalidCredentials = domain.Unauthorized("invalid email or password")
+
+// dummyPasswordHash 는 없는 이메일로 로그인할 때도 bcrypt 비교를 한 번 해서 응답 시간으로 가입 여부가 드러나지 않게 한다
+// util.HashPassword 와 같은 cost(bcrypt.DefaultCost) 여야 한다
+const dummyPasswordHash = "$2a$10$LdiVsrNEHZDNBIXr5njNien9sI6d51rYZbsrfVyp8X6472T1f.2W6"
+
 type AuthService interface {
 	Login(context context.Context, user *user.User) (string, error)
 	Logout(context context.Context, token string) error
@@ -22,30 +36,41 @@ type authService struct {
 	userRepo user.Repository
 	cache    Cache
 	secret   []byte
+	tokenTTL time.Duration
 }
 
-func NewAuthService(userRepo user.Repository, cache Cache, secret string) AuthService {
+func NewAuthService(userRepo user.Repository, cache Cache, secret string, tokenTTL time.Duration) AuthService {
 	return &authService{
 		userRepo: userRepo,
 		cache:    cache,
 		secret:   []byte(secret),
+		tokenTTL: tokenTTL,
 	}
 }
 
 func (a *authService) Login(context context.Context, user *user.User) (string, error) {
-	storedUser, err := a.userRepo.FindByEmail(context, user.Email)
-	if err != nil {
+	storedUser, err := a.userRepo.FindCredentials(context, user.Email)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
 		return "", err
 	}
-	matched := storedUser.CheckPassword(user.Password)
+	_, span := otel.Tracer("module.resume/application").Start(context, "bcrypt.compare")
+	var matched bool
+	if storedUser == nil {
+		util.CheckPasswordHash(user.Password, dummyPasswordHash)
+	} else {
+		matched = storedUser.CheckPassword(user.Password)
+	}
+	span.End()
 	if !matched {
-		return "", errors.New("invalid password")
+		return "", errInvalidCredentials
 	}
 
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"sub": storedUser.Email,
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(time.Hour * 1).Unix(),
+		"uid": storedUser.ID,
+		"iat": jwt.NewNumericDate(now),
+		"exp": now.Add(a.tokenTTL).Unix(),
 		"iss": "module-resume-server",
 	}
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -81,14 +106,34 @@ func (a *authService) Logout(context context.Context, token string) error {
 func (a *authService) Authenticate(ctx context.Context, token string) (*auth.Claims, error) {
 	key := "blocklist:" + token
 	val, err := a.cache.Get(ctx, key)
-	if err != nil && !errors.Is(err, redis.Nil) {
+	if err != nil && !errors.Is(err, ErrCacheMiss) {
 		return nil, err
 	}
 	if val != "" {
-		return nil, errors.New("token is blocklisted")
+		return nil, ErrTokenBlocklisted
 	}
 
-	return a.parseToken(token)
+	claims, err := a.parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 비밀번호 변경 등으로 그 전에 발급된 토큰이 모두 무효가 되었는지 확인한다
+	// email 은 바뀐 뒤 다른 계정이 가져갈 수 있으므로 요청을 처리할 때 쓰는 uid 로 찾는다
+	storedUser, err := a.userRepo.FindByID(ctx, claims.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.Unauthorized("user no longer exists")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if storedUser.Email != claims.Subject {
+		return nil, ErrTokenRevoked
+	}
+	if claims.IssuedAt == nil || storedUser.TokenRevoked(claims.IssuedAt.Time) {
+		return nil, ErrTokenRevoked
+	}
+	return claims, nil
 }
 
 func (a *authService) parseToken(tokenString string) (*auth.Claims, error) {
@@ -99,12 +144,12 @@ func (a *authService) parseToken(tokenString string) (*auth.Claims, error) {
 		return a.secret, nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, domain.Unauthorized(err.Error())
 	}
 
 	if claims, ok := token.Claims.(*auth.Claims); ok && token.Valid {
 		return claims, nil
 	}
 
-	return nil, errors.New("invalid token")
+	return nil, domain.Unauthorized("invalid token")
 }
@@ -9,6 +9,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"module.resume/internal/domain"
 	"module.resume/internal/domain/user"
 	"module.resume/internal/util"
 )
@@ -29,9 +32,38 @@ func (m *MockCache) Set(ctx context.Context, key string, value interface{}, expi
 	return args.Error(0)
 }
 
+func (m *MockCache) Delete(ctx context.Context, keys ...string) error {
+	args := m.Called(ctx, keys)
+	return args.Error(0)
+}
+
+func (m *MockCache) Exists(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCache) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	args := m.Called(ctx, key, expiration)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	args := m.Called(ctx, keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
 func generateTestToken(t *testing.T, email string, secret string, expiresAt time.Time) string {
 	claims := jwt.MapClaims{
 		"sub": email,
+		"uid": 1,
 		"iat": time.Now().Unix(),
 		"exp": expiresAt.Unix(),
 		"iss": "module-resume-server",
@@ -45,7 +77,7 @@ func generateTestToken(t *testing.T, email string, secret string, expiresAt time
 func TestAuthService_Login(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockCache := new(MockCache)
-	authService := NewAuthService(mockUserRepo, mockCache, testSecret)
+	authService := NewAuthService(mockUserRepo, mockCache, testSecret, time.Hour)
 	ctx := context.Background()
 
 	email := "test@example.com"
@@ -58,7 +90,7 @@ func TestAuthService_Login(t *testing.T) {
 	storedUser.SetPasswordHash(hashedPassword)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("FindByEmail", ctx, email).Return(storedUser, nil).Once()
+		mockUserRepo.On("FindCredentials", ctx, email).Return(storedUser, nil).Once()
 
 		token, err := authService.Login(ctx, loginAttemptUser)
 
@@ -68,7 +100,7 @@ func TestAuthService_Login(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockUserRepo.On("FindByEmail", ctx, email).Return(nil, errors.New("user not found")).Once()
+		mockUserRepo.On("FindCredentials", ctx, email).Return(nil, errors.New("user not found")).Once()
 
 		token, err := authService.Login(ctx, loginAttemptUser)
 
@@ -80,21 +112,40 @@ func TestAuthService_Login(t *testing.T) {
 
 	t.Run("invalid password", func(t *testing.T) {
 		wrongPasswordUser := &user.User{Email: email, Password: "wrong-password"}
-		mockUserRepo.On("FindByEmail", ctx, email).Return(storedUser, nil).Once()
+		mockUserRepo.On("FindCredentials", ctx, email).Return(storedUser, nil).Once()
 
 		token, err := authService.Login(ctx, wrongPasswordUser)
 
 		assert.Error(t, err)
 		assert.Empty(t, token)
-		assert.Equal(t, "invalid password", err.Error())
+		assert.Equal(t, "invalid email or password", err.Error())
 		mockUserRepo.AssertExpectations(t)
 	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockUserRepo.On("FindCredentials", ctx, email).Return(nil, domain.NotFound("user not found")).Once()
+
+		token, err := authService.Login(ctx, loginAttemptUser)
+
+		assert.Empty(t, token)
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, "invalid email or password", err.Error())
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("dummy hash costs the same as real hashes", func(t *testing.T) {
+		dummyCost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+		assert.NoError(t, err)
+		realCost, err := bcrypt.Cost([]byte(hashedPassword))
+		assert.NoError(t, err)
+		assert.Equal(t, realCost, dummyCost)
+	})
 }
 
 func TestAuthService_Logout(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockCache := new(MockCache)
-	authService := NewAuthService(mockUserRepo, mockCache, testSecret)
+	authService := NewAuthService(mockUserRepo, mockCache, testSecret, time.Hour)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -118,13 +169,14 @@ func TestAuthService_Logout(t *testing.T) {
 func TestAuthService_Authenticate(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockCache := new(MockCache)
-	authService := NewAuthService(mockUserRepo, mockCache, testSecret)
+	authService := NewAuthService(mockUserRepo, mockCache, testSecret, time.Hour)
 	ctx := context.Background()
 	email := "user@example.com"
 
 	t.Run("success", func(t *testing.T) {
 		token := generateTestToken(t, email, testSecret, time.Now().Add(time.Hour))
-		mockCache.On("Get", ctx, "blocklist:"+token).Return("", nil).Once()
+		mockCache.On("Get", ctx, "blocklist:"+token).Return("", ErrCacheMiss).Once()
+		mockUserRepo.On("FindByID", ctx, uint(1)).Return(&user.User{ID: 1, Email: email}, nil).Once()
 
 		claims, err := authService.Authenticate(ctx, token)
 
@@ -132,6 +184,97 @@ func TestAuthService_Authenticate(t *testing.T) {
 		assert.NotNil(t, claims)
 		assert.Equal(t, email, claims.Subject)
 		mockCache.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("token issued before password change", func(t *testing.T) {
+		token := generateTestToken(t, email, testSecret, time.Now().Add(time.Hour))
+		changedAt := time.Now().Add(time.Second)
+		mockCache.On("Get", ctx, "blocklist:"+token).Return("", ErrCacheMiss).Once()
+		mockUserRepo.On("FindByID", ctx, uint(1)).
+			Return(&user.User{ID: 1, Email: email, TokensInvalidBefore: &changedAt}, nil).Once()
+
+		claims, err := authService.Authenticate(ctx, token)
+
+		assert.Nil(t, claims)
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("password changed right after login", func(t *testing.T) {
+		stored := newStoredUser(t, email, "password-1234")
+		mockUserRepo.On("FindCredentials", ctx, email).Return(stored, nil).Once()
+		token, err := authService.Login(ctx, user.NewUserForLogin(email, "password-1234"))
+		require.NoError(t, err)
+
+		// 로그인과 같은 초에 무효화해도 그 토큰은 통하지 않는다
+		changedAt := time.Now()
+		mockCache.On("Get", ctx, "blocklist:"+token).Return("", ErrCacheMiss).Once()
+		mockUserRepo.On("FindByID", ctx, uint(1)).
+			Return(&user.User{ID: 1, Email: email, TokensInvalidBefore: &changedAt}, nil).Once()
+
+		claims, err := authService.Authenticate(ctx, token)
+
+		assert.Nil(t, claims)
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("login right after password change", func(t *testing.T) {
+		changedAt := time.Now()
+		time.Sleep(2 * time.Millisecond)
+		stored := newStoredUser(t, email, "password-1234")
+		mockUserRepo.On("FindCredentials", ctx, email).Return(stored, nil).Once()
+		token, err := authService.Login(ctx, user.NewUserForLogin(email, "password-1234"))
+		require.NoError(t, err)
+
+		mockCache.On("Get", ctx, "blocklist:"+token).Return("", ErrCacheMiss).Once()
+		mockUserRepo.On("FindByID", ctx, uint(1)).
+			Return(&user.User{ID: 1, Email: email, TokensInvalidBefore: &changedAt}, nil).Once()
+
+		claims, err := authService.Authenticate(ctx, token)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, claims)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("email re-registered by another account", func(t *testing.T) {
+		// uid 1 이 email 을 바꾼 뒤 다른 사람이 예전 email 로 가입해도 예전 토큰은 통하지 않는다
+		token := generateTestToken(t, email, testSecret, time.Now().Add(time.Hour))
+		mockCache.On("Get", ctx, "blocklist:"+token).Return("", ErrCacheMiss).Once()
+		mockUserRepo.On("FindByID", ctx, uint(1)).Return(&user.User{ID: 1, Email: "changed@example.com"}, nil).Once()
+
+		claims, err := authService.Authenticate(ctx, token)
+
+		assert.Nil(t, claims)
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+		mockUserRepo.AssertNotCalled(t, "FindByEmail", ctx, email)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("user no longer exists", func(t *testing.T) {
+		token := generateTestToken(t, email, testSecret, time.Now().Add(time.Hour))
+		mockCache.On("Get", ctx, "blocklist:"+token).Return("", ErrCacheMiss).Once()
+		mockUserRepo.On("FindByID", ctx, uint(1)).Return(nil, domain.NotFound("user not found")).Once()
+
+		claims, err := authService.Authenticate(ctx, token)
+
+		assert.Nil(t, claims)
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		token := generateTestToken(t, email, testSecret, time.Now().Add(time.Hour))
+		mockCache.On("Get", ctx, "blocklist:"+token).Return("", errors.New("connection refused")).Once()
+
+		claims, err := authService.Authenticate(ctx, token)
+
+		assert.Error(t, err)
+		assert.Nil(t, claims)
+		assert.Equal(t, "connection refused", err.Error())
+		mockCache.AssertExpectations(t)
 	})
 
 	t.Run("token is blocklisted", func(t *testing.T) {
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"module.resume/internal/auth"
+	"module.resume/internal/domain/user"
+	"module.resume/internal/imaging"
+)
+
+// AvatarSize 는 저장하는 프로필 사진의 가로세로 픽셀
+const AvatarSize = 256
+
+// AvatarService 는 탈퇴한 사용자의 사진을 지우는 UserDataEraser 이기도 하다
+type AvatarService interface {
+	UserDataEraser
+	// Upload 는 이미지를 정사각형으로 줄여 저장하고 예전 사진을 지운다
+	Upload(ctx context.Context, userID uint, data []byte) (*user.User, error)
+	Remove(ctx context.Context, userID uint) error
+	// URL 은 사진을 여는 서명된 링크. 사진이 없으면 빈 문자열
+	URL(u *user.User) string
+	Open(ctx context.Context, userID, name, expires, signature string) (*Blob, error)
+}
+
+type avatarService struct {
+	users     user.Repository
+	blobs     BlobStore
+	tx        TxManager
+	signer    *auth.URLSigner
+	publicURL string
+	// urlTTL 동안 응답에 담긴 링크를 열 수 있다
+	urlTTL time.Duration
+}
+
+func NewAvatarService(users user.Repository, blobs BlobStore, tx TxManager, signer *auth.URLSigner,
+	publicURL string, urlTTL time.Duration) AvatarService {
+	return &avatarService{
+		users:     users,
+		blobs:     blobs,
+		tx:        tx,
+		signer:    signer,
+		publicURL: publicURL,
+		urlTTL:    urlTTL,
+	}
+}
+
+// Upload 는 파일을 먼저 올리고 key 를 저장한다. 저장에 실패하면 올린 파일을 지운다
+// key 는 매번 새로 만들어서 서명된 예전 링크가 새 사진을 가리키지 않게 한다
+func (s *avatarService) Upload(ctx context.Context, userID uint, data []byte) (*user.User, error) {
+	processed, contentType, err := imaging.Avatar(data, AvatarSize)
+	if err != nil {
+		return nil, err
+	}
+	ext := ".png"
+	if contentType == "image/jpeg" {
+		ext = ".jpg"
+	}
+	key := avatarPrefix(userID) + newToken() + ext
+	if err := s.blobs.Put(ctx, key, contentType, processed); err != nil {
+		return nil, err
+	}
+
+	var updated *user.User
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.users.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		previous := stored.AvatarKey
+		stored.AvatarKey = key
+		if err := s.users.UpdateAvatar(ctx, stored); err != nil {
+			return err
+		}
+		s.deleteAfterCommit(ctx, previous)
+		updated = stored
+		return nil
+	})
+	if err != nil {
+		_ = s.blobs.Delete(context.WithoutCancel(ctx), key)
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *avatarService) Remove(ctx context.Context, userID uint) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.users.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if stored.AvatarKey == "" {
+			return nil
+		}
+		previous := stored.AvatarKey
+		stored.AvatarKey = ""
+		if err := s.users.UpdateAvatar(ctx, stored); err != nil {
+			return err
+		}
+		s.deleteAfterCommit(ctx, previous)
+		return nil
+	})
+}
+
+// deleteAfterCommit 은 커밋된 뒤에만 예전 파일을 지운다. 실패해도 탈퇴 시 EraseUserData 가 남은 파일을 지운다
+func (s *avatarService) deleteAfterCommit(ctx context.Context, key string) {
+	if key == "" {
+		return
+	}
+	AfterCommit(ctx, func() {
+		_ = s.blobs.Delete(context.WithoutCancel(ctx), key)
+	})
+}
+
+func (s *avatarService) URL(u *user.User) string {
+	if u.AvatarKey == "" {
+		return ""
+	}
+	return s.publicURL + s.signer.Sign(avatarPath(u.AvatarKey), time.Now().Add(s.urlTTL))
+}
+
+// Open 은 서명을 먼저 확인하므로 서명하지 않은 key 로는 저장소에 닿지 않는다
+func (s *avatarService) Open(ctx context.Context, userID, name, expires, signature string) (*Blob, error) {
+	key := "avatars/" + userID + "/" + name
+	if err := s.signer.Verify(avatarPath(key), expires, signature); err != nil {
+		return nil, err
+	}
+	return s.blobs.Get(ctx, key)
+}
+
+// EraseUserData 는 탈퇴한 사용자의 사진을 예전에 지우지 못한 것까지 모두 지운다
+func (s *avatarService) EraseUserData(ctx context.Context, userID uint) error {
+	return s.blobs.DeletePrefix(ctx, avatarPrefix(userID))
+}
+
+func avatarPrefix(userID uint) string {
+	return "avatars/" + strconv.FormatUint(uint64(userID), 10) + "/"
+}
+
+// avatarPath 는 key(avatars/1/abc.jpg)를 여는 API 경로(/user/avatar/1/abc.jpg)
+func avatarPath(key string) string {
+	return "/user/avatar/" + strings.TrimPrefix(key, "avatars/")
+}
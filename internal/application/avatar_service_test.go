@@ -0,0 +1,135 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/auth"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/user"
+)
+
+type fakeBlobStore struct {
+	blobs map[string][]byte
+}
+
+func (f *fakeBlobStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	f.blobs[key] = data
+	return nil
+}
+
+func (f *fakeBlobStore) Get(ctx context.Context, key string) (*Blob, error) {
+	data, ok := f.blobs[key]
+	if !ok {
+		return nil, domain.NotFound("file not found")
+	}
+	return &Blob{Body: io.NopCloser(bytes.NewReader(data)), ContentType: "image/png", Size: int64(len(data))}, nil
+}
+
+func (f *fakeBlobStore) Delete(ctx context.Context, key string) error {
+	delete(f.blobs, key)
+	return nil
+}
+
+func (f *fakeBlobStore) DeletePrefix(ctx context.Context, prefix string) error {
+	for key := range f.blobs {
+		if strings.HasPrefix(key, prefix) {
+			delete(f.blobs, key)
+		}
+	}
+	return nil
+}
+
+func testPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 10, 10))))
+	return buf.Bytes()
+}
+
+func TestAvatarService(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (AvatarService, *MockUserRepository, *fakeBlobStore, *user.User) {
+		users, blobs := new(MockUserRepository), &fakeBlobStore{blobs: map[string][]byte{}}
+		stored := &user.User{ID: 1, Email: "avatar@example.com"}
+		users.On("FindByID", ctx, uint(1)).Return(stored, nil)
+		service := NewAvatarService(users, blobs, fakeTxManager{}, auth.NewURLSigner("key"), "https://api.example.com", time.Hour)
+		return service, users, blobs, stored
+	}
+
+	t.Run("upload replaces the previous photo and serves it by signed url", func(t *testing.T) {
+		service, users, blobs, stored := setup()
+		users.On("UpdateAvatar", ctx, stored).Return(nil)
+
+		_, err := service.Upload(ctx, 1, testPNG(t))
+		require.NoError(t, err)
+		first := stored.AvatarKey
+		_, err = service.Upload(ctx, 1, testPNG(t))
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, stored.AvatarKey)
+		assert.Len(t, blobs.blobs, 1)
+		assert.Contains(t, blobs.blobs, stored.AvatarKey)
+
+		link, err := url.Parse(service.URL(stored))
+		require.NoError(t, err)
+		parts := strings.Split(strings.TrimPrefix(link.Path, "/user/avatar/"), "/")
+		require.Len(t, parts, 2)
+		blob, err := service.Open(ctx, parts[0], parts[1], link.Query().Get("expires"), link.Query().Get("signature"))
+		require.NoError(t, err)
+		blob.Body.Close()
+
+		_, err = service.Open(ctx, "2", parts[1], link.Query().Get("expires"), link.Query().Get("signature"))
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("failed save removes the upload", func(t *testing.T) {
+		service, users, blobs, stored := setup()
+		users.On("UpdateAvatar", ctx, stored).Return(errors.New("db down"))
+
+		_, err := service.Upload(ctx, 1, testPNG(t))
+
+		assert.Error(t, err)
+		assert.Empty(t, blobs.blobs)
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		service, users, blobs, _ := setup()
+
+		_, err := service.Upload(ctx, 1, []byte("%PDF-1.7"))
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Empty(t, blobs.blobs)
+		users.AssertNotCalled(t, "UpdateAvatar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("remove and erase", func(t *testing.T) {
+		service, users, blobs, stored := setup()
+		users.On("UpdateAvatar", ctx, stored).Return(nil)
+		_, err := service.Upload(ctx, 1, testPNG(t))
+		require.NoError(t, err)
+
+		require.NoError(t, service.Remove(ctx, 1))
+		assert.Empty(t, stored.AvatarKey)
+		assert.Empty(t, service.URL(stored))
+		assert.Empty(t, blobs.blobs)
+
+		blobs.blobs["avatars/1/leftover.png"] = []byte("png")
+		blobs.blobs["avatars/10/other.png"] = []byte("png")
+		require.NoError(t, service.EraseUserData(ctx, 1))
+		assert.Equal(t, []string{"avatars/10/other.png"}, slices.Collect(maps.Keys(blobs.blobs)))
+	})
+}
@@ -0,0 +1,25 @@
+package application
+
+import (
+	"context"
+	"io"
+)
+
+// Blob 은 BlobStore 에서 읽은 파일. 다 읽은 뒤 Body 를 닫아야 한다
+type Blob struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// BlobStore 는 프로필 사진처럼 DB 에 두기 큰 파일을 저장한다
+// key 는 "avatars/1/abc.jpg" 처럼 / 로 구분한 경로다
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Get 은 key 가 없으면 NotFound 를 돌려준다
+	Get(ctx context.Context, key string) (*Blob, error)
+	// Delete 는 key 가 없어도 에러가 아니다
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix 는 prefix 로 시작하는 파일을 모두 지운다
+	DeletePrefix(ctx context.Context, prefix string) error
+}
@@ -2,10 +2,21 @@ package application
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCacheMiss 는 키가 없거나 만료되었을 때 모든 Cache 구현체가 반환하는 에러
+var ErrCacheMiss = errors.New("cache miss")
+
 type Cache interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Get(ctx context.Context, key string) (string, error)
+	Delete(ctx context.Context, keys ...string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	// Incr 는 키가 새로 만들어질 때만 expiration 을 설정한다
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	// MGet 은 존재하는 키만 결과에 담는다
+	MGet(ctx context.Context, keys ...string) (map[string]string, error)
 }
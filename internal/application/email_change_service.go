@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/user"
+)
+
+type EmailChangeService interface {
+	// Request 는 새 주소로 확인 링크를, 예전 주소로 되돌리기 링크가 담긴 알림을 보낸다
+	Request(ctx context.Context, userID uint, newEmail, password string) error
+	// Confirm 과 Undo 는 처리한 요청을 돌려준다
+	Confirm(ctx context.Context, token string) (*user.EmailChange, error)
+	Undo(ctx context.Context, token string) (*user.EmailChange, error)
+}
+
+type emailChangeService struct {
+	users     user.Repository
+	changes   user.EmailChangeRepository
+	tx        TxManager
+	outbox    Outbox
+	mailer    Mailer
+	publicURL string
+	// ttl 안에 확인해야 한다
+	ttl time.Duration
+	// undoWindow 동안은 예전 주소에서 되돌릴 수 있다
+	undoWindow time.Duration
+}
+
+func NewEmailChangeService(users user.Repository, changes user.EmailChangeRepository, tx TxManager, outbox Outbox, mailer Mailer,
+	publicURL string, ttl, undoWindow time.Duration) EmailChangeService {
+	return &emailChangeService{
+		users:      users,
+		changes:    changes,
+		tx:         tx,
+		outbox:     outbox,
+		mailer:     mailer,
+		publicURL:  publicURL,
+		ttl:        ttl,
+		undoWindow: undoWindow,
+	}
+}
+
+// Request 는 이전 요청을 취소하고 새 요청을 만든다. 메일을 못 보내면 요청도 롤백한다
+func (s *emailChangeService) Request(ctx context.Context, userID uint, newEmail, password string) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.users.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !stored.CheckPassword(password) {
+			return errInvalidPassword
+		}
+		if strings.EqualFold(stored.Email, newEmail) {
+			return domain.Validation("request validation failed",
+				domain.FieldError{Field: "email", Message: "must differ from the current email"})
+		}
+		_, err = s.users.FindByEmail(ctx, newEmail)
+		if err == nil {
+			return domain.Conflict("email is already registered")
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+
+		if err := s.changes.CancelPending(ctx, stored.ID); err != nil {
+			return err
+		}
+		confirmToken, undoToken := newToken(), newToken()
+		change := user.NewEmailChange(stored, newEmail, hashToken(confirmToken), hashToken(undoToken), s.ttl, s.undoWindow)
+		if err := s.changes.Create(ctx, change); err != nil {
+			return err
+		}
+
+		if err := s.mailer.Send(ctx, Mail{
+			To:      newEmail,
+			Subject: "Confirm your new email address",
+			Body: fmt.Sprintf("Confirm this address before %s to use it for your account:\n\n%s\n",
+				change.ExpiresAt.UTC().Format(time.RFC1123), s.link("/user/email/confirm", confirmToken)),
+		}); err != nil {
+			return err
+		}
+		return s.mailer.Send(ctx, Mail{
+			To:      stored.Email,
+			Subject: "Your email address is being changed",
+			Body: fmt.Sprintf("A change of your account email to %s was requested. "+
+				"If this wasn't you, undo it before %s:\n\n%s\n",
+				newEmail, change.UndoExpiresAt.UTC().Format(time.RFC1123), s.link("/user/email/undo", undoToken)),
+		})
+	})
+}
+
+// Confirm 은 email 을 바꾸고 예전 주소로 받은 토큰을 포함한 모든 토큰을 무효로 만든다
+func (s *emailChangeService) Confirm(ctx context.Context, token string) (*user.EmailChange, error) {
+	var confirmed *user.EmailChange
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		change, err := s.changes.FindByConfirmToken(ctx, hashToken(token))
+		if err != nil {
+			return err
+		}
+		from := change.Status
+		if err := change.Confirm(time.Now()); err != nil {
+			return err
+		}
+		if err := s.changes.Transition(ctx, change, from); err != nil {
+			return err
+		}
+
+		stored, err := s.users.FindByID(ctx, change.UserID)
+		if err != nil {
+			return err
+		}
+		if stored.Email != change.OldEmail {
+			return domain.Conflict("email was changed after this link was sent")
+		}
+		stored.ChangeEmail(change.NewEmail)
+		if err := s.users.UpdateEmail(ctx, stored, change.OldEmail); err != nil {
+			return err
+		}
+		confirmed = change
+		return s.outbox.Append(ctx, stored.PullEvents()...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return confirmed, nil
+}
+
+// Undo 는 확인 전이면 요청을 취소하고, 이미 바뀌었으면 예전 주소로 되돌린 뒤 모든 토큰을 무효로 만든다
+func (s *emailChangeService) Undo(ctx context.Context, token string) (*user.EmailChange, error) {
+	var undone *user.EmailChange
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		change, err := s.changes.FindByUndoToken(ctx, hashToken(token))
+		if err != nil {
+			return err
+		}
+		from := change.Status
+		revert, err := change.Undo(time.Now())
+		if err != nil {
+			return err
+		}
+		if err := s.changes.Transition(ctx, change, from); err != nil {
+			return err
+		}
+		undone = change
+		if !revert {
+			return nil
+		}
+
+		stored, err := s.users.FindByID(ctx, change.UserID)
+		if err != nil {
+			return err
+		}
+		// 그 뒤에 또 바뀌었으면 이 요청이 만든 주소가 아니므로 건드리지 않는다
+		if stored.Email != change.NewEmail {
+			return domain.Conflict("email was changed again after this link was sent")
+		}
+		stored.RevertEmail(change.OldEmail)
+		if err := s.users.UpdateEmail(ctx, stored, change.NewEmail); err != nil {
+			return err
+		}
+		return s.outbox.Append(ctx, stored.PullEvents()...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return undone, nil
+}
+
+func (s *emailChangeService) link(path, token string) string {
+	return s.publicURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// newToken 은 메일 링크에 넣을 추측할 수 없는 토큰을 만든다
+func newToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// hashToken 은 저장용 해시. DB 가 새어도 토큰 원문은 알 수 없다
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/user"
+)
+
+type fakeEmailChangeRepository struct {
+	changes []*user.EmailChange
+}
+
+func (f *fakeEmailChangeRepository) Create(ctx context.Context, c *user.EmailChange) error {
+	c.ID = int64(len(f.changes) + 1)
+	copied := *c
+	f.changes = append(f.changes, &copied)
+	return nil
+}
+
+func (f *fakeEmailChangeRepository) FindByConfirmToken(ctx context.Context, tokenHash string) (*user.EmailChange, error) {
+	return f.find(func(c *user.EmailChange) bool { return c.ConfirmTokenHash == tokenHash })
+}
+
+func (f *fakeEmailChangeRepository) FindByUndoToken(ctx context.Context, tokenHash string) (*user.EmailChange, error) {
+	return f.find(func(c *user.EmailChange) bool { return c.UndoTokenHash == tokenHash })
+}
+
+func (f *fakeEmailChangeRepository) find(match func(c *user.EmailChange) bool) (*user.EmailChange, error) {
+	for _, c := range f.changes {
+		if match(c) {
+			copied := *c
+			return &copied, nil
+		}
+	}
+	return nil, domain.NotFound("link is invalid or has expired")
+}
+
+func (f *fakeEmailChangeRepository) CancelPending(ctx context.Context, userID uint) error {
+	for _, c := range f.changes {
+		if c.UserID == userID && c.Status == user.EmailChangePending {
+			c.Status = user.EmailChangeCancelled
+		}
+	}
+	return nil
+}
+
+func (f *fakeEmailChangeRepository) Transition(ctx context.Context, c *user.EmailChange, from string) error {
+	for _, stored := range f.changes {
+		if stored.ID == c.ID && stored.Status == from {
+			stored.Status = c.Status
+			return nil
+		}
+	}
+	return domain.NotFound("link is invalid or has expired")
+}
+
+// tokenFrom 은 메일 본문의 링크에서 토큰을 꺼낸다
+func tokenFrom(t *testing.T, mail Mail) string {
+	i := strings.Index(mail.Body, "?token=")
+	require.GreaterOrEqual(t, i, 0)
+	token, err := url.QueryUnescape(strings.TrimSpace(mail.Body[i+len("?token="):]))
+	require.NoError(t, err)
+	return token
+}
+
+func TestEmailChangeService(t *testing.T) {
+	ctx := context.Background()
+	oldEmail, newEmail, password := "old@example.com", "new@example.com", "current-password"
+
+	setup := func(t *testing.T) (*emailChangeService, *MockUserRepository, *fakeEmailChangeRepository, *fakeMailer, *user.User) {
+		users, outbox := new(MockUserRepository), new(MockOutbox)
+		outbox.On("Append", mock.Anything, mock.Anything).Return(nil)
+		changes, mailer := &fakeEmailChangeRepository{}, &fakeMailer{}
+		stored := newStoredUser(t, oldEmail, password)
+		users.On("FindByID", ctx, uint(1)).Return(stored, nil)
+		service := NewEmailChangeService(users, changes, fakeTxManager{}, outbox, mailer,
+			"https://api.example.com", time.Hour, 24*time.Hour).(*emailChangeService)
+		return service, users, changes, mailer, stored
+	}
+
+	t.Run("request, confirm and undo", func(t *testing.T) {
+		service, users, changes, mailer, stored := setup(t)
+		users.On("FindByEmail", ctx, newEmail).Return(nil, domain.NotFound("user not found")).Once()
+		users.On("UpdateEmail", ctx, stored, oldEmail).Return(nil).Once()
+		users.On("UpdateEmail", ctx, stored, newEmail).Return(nil).Once()
+
+		require.NoError(t, service.Request(ctx, 1, newEmail, password))
+		require.Len(t, mailer.sent, 2)
+		assert.Equal(t, newEmail, mailer.sent[0].To)
+		assert.Equal(t, oldEmail, mailer.sent[1].To)
+		assert.Equal(t, oldEmail, stored.Email)
+		// 토큰 원문은 저장하지 않는다
+		confirmToken, undoToken := tokenFrom(t, mailer.sent[0]), tokenFrom(t, mailer.sent[1])
+		assert.NotEqual(t, confirmToken, changes.changes[0].ConfirmTokenHash)
+
+		_, err := service.Confirm(ctx, confirmToken)
+		require.NoError(t, err)
+		assert.Equal(t, newEmail, stored.Email)
+		assert.True(t, stored.TokenRevoked(time.Now().Add(-time.Second)))
+		_, err = service.Confirm(ctx, confirmToken)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		undone, err := service.Undo(ctx, undoToken)
+		require.NoError(t, err)
+		assert.Equal(t, user.EmailChangeUndone, undone.Status)
+		assert.Equal(t, oldEmail, stored.Email)
+		assert.Equal(t, user.EmailChangeUndone, changes.changes[0].Status)
+		users.AssertExpectations(t)
+	})
+
+	t.Run("new request cancels the previous one", func(t *testing.T) {
+		service, users, changes, mailer, _ := setup(t)
+		users.On("FindByEmail", ctx, mock.Anything).Return(nil, domain.NotFound("user not found"))
+
+		require.NoError(t, service.Request(ctx, 1, newEmail, password))
+		require.NoError(t, service.Request(ctx, 1, "other@example.com", password))
+
+		assert.Equal(t, user.EmailChangeCancelled, changes.changes[0].Status)
+		_, err := service.Confirm(ctx, tokenFrom(t, mailer.sent[0]))
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		service, _, changes, mailer, _ := setup(t)
+
+		assert.ErrorIs(t, service.Request(ctx, 1, newEmail, "wrong-password"), domain.ErrUnauthorized)
+		assert.Empty(t, changes.changes)
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("email already registered", func(t *testing.T) {
+		service, users, _, mailer, _ := setup(t)
+		users.On("FindByEmail", ctx, newEmail).Return(&user.User{ID: 2, Email: newEmail}, nil).Once()
+
+		assert.ErrorIs(t, service.Request(ctx, 1, newEmail, password), domain.ErrConflict)
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("same email", func(t *testing.T) {
+		service, _, _, _, _ := setup(t)
+
+		assert.ErrorIs(t, service.Request(ctx, 1, "OLD@example.com", password), domain.ErrValidation)
+	})
+}
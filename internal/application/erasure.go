@@ -0,0 +1,10 @@
+package application
+
+import "context"
+
+// UserDataEraser 는 탈퇴 유예 기간이 끝난 사용자에게 딸린 데이터를 지운다
+// 사용자 데이터를 가진 저장소(이력서, 공유 링크, 업로드 등)가 생기면 이것을 구현해 익명화 잡에 등록한다
+// 같은 사용자에 대해 여러 번 호출될 수 있으므로 멱등이어야 한다
+type UserDataEraser interface {
+	EraseUserData(ctx context.Context, userID uint) error
+}
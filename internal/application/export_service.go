@@ -0,0 +1,218 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"module.resume/internal/auth"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/export"
+	"module.resume/internal/domain/user"
+	"module.resume/internal/infrastructure/logging"
+)
+
+// TaskBuildExport 는 데이터 내보내기 ZIP 을 만드는 작업
+const TaskBuildExport = "user.export"
+
+type BuildExportPayload struct {
+	ExportID int64  `json:"export_id"`
+	Email    string `json:"email"`
+}
+
+// UserDataExporter 는 데이터 내보내기 ZIP 에 자기 데이터를 파일 하나로 넣는다
+// 사용자 데이터를 가진 저장소(이력서, 감사 로그 등)가 구현해 등록한다
+type UserDataExporter interface {
+	// ExportUserData 는 ZIP 안의 파일 이름과 JSON 으로 쓸 값을 돌려준다
+	ExportUserData(ctx context.Context, userID uint) (name string, data interface{}, err error)
+}
+
+type ExportService interface {
+	Request(ctx context.Context, userID uint) (*export.Export, error)
+	Build(ctx context.Context, payload BuildExportPayload) error
+	Download(ctx context.Context, id int64, expires, signature string) ([]byte, error)
+}
+
+type exportService struct {
+	users     user.Repository
+	exports   export.Repository
+	tx        TxManager
+	queue     JobQueue
+	mailer    Mailer
+	signer    *auth.URLSigner
+	publicURL string
+	// ttl 동안 내려받을 수 있다
+	ttl       time.Duration
+	exporters []UserDataExporter
+}
+
+func NewExportService(users user.Repository, exports export.Repository, tx TxManager, queue JobQueue, mailer Mailer,
+	signer *auth.URLSigner, publicURL string, ttl time.Duration, exporters ...UserDataExporter) ExportService {
+	return &exportService{
+		users:     users,
+		exports:   exports,
+		tx:        tx,
+		queue:     queue,
+		mailer:    mailer,
+		signer:    signer,
+		publicURL: publicURL,
+		ttl:       ttl,
+		exporters: exporters,
+	}
+}
+
+// Request 는 내보내기를 등록하고 ZIP 생성 작업을 큐에 넣는다. 이미 만들고 있는 것이 있으면 그것을 돌려준다
+func (s *exportService) Request(ctx context.Context, userID uint) (*export.Export, error) {
+	var requested *export.Export
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.users.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		pending, err := s.exports.FindPending(ctx, stored.ID)
+		if err == nil {
+			requested = pending
+			return nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+
+		requested = export.New(stored.ID)
+		if err := s.exports.Create(ctx, requested); err != nil {
+			return err
+		}
+		return s.queue.Enqueue(ctx, Task{
+			Kind:           TaskBuildExport,
+			Payload:        BuildExportPayload{ExportID: requested.ID, Email: stored.Email},
+			IdempotencyKey: TaskBuildExport + ":" + strconv.FormatInt(requested.ID, 10),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return requested, nil
+}
+
+// Build 는 ZIP 을 만들어 저장하고 다운로드 링크를 메일로 보낸다
+// 에러를 돌려주면 큐가 다시 시도하므로 여러 번 호출되어도 안전해야 한다
+func (s *exportService) Build(ctx context.Context, payload BuildExportPayload) error {
+	requested, err := s.exports.Find(ctx, payload.ExportID)
+	if err != nil {
+		return err
+	}
+	if requested.Status != export.StatusPending {
+		return nil
+	}
+
+	// 요청 뒤에 탈퇴했거나 email 이 바뀌었으면 다시 요청해야 한다
+	stored, err := s.users.FindByEmail(ctx, payload.Email)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && stored.ID != requested.UserID) {
+		return s.exports.MarkFailed(ctx, requested.ID, "account changed after the export was requested")
+	}
+	if err != nil {
+		return err
+	}
+
+	archive, err := s.archive(ctx, stored)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(s.ttl)
+	link := s.publicURL + s.signer.Sign(downloadPath(requested.ID), expiresAt)
+	return s.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.exports.MarkReady(txCtx, requested.ID, archive, expiresAt); err != nil {
+			return err
+		}
+		// 메일 서버를 기다리는 동안 트랜잭션을 붙잡지 않고, 롤백된 내보내기의 링크를 보내지 않도록 커밋 뒤에 보낸다
+		// 링크가 담긴 본문은 로그에 남기지 않는다
+		AfterCommit(txCtx, func() {
+			ctx := context.WithoutCancel(ctx)
+			err := s.mailer.Send(ctx, Mail{
+				To:      stored.Email,
+				Subject: "Your data export is ready",
+				Body: fmt.Sprintf("Your data export is ready. Download it before %s:\n\n%s\n",
+					expiresAt.UTC().Format(time.RFC1123), link),
+			})
+			if err != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "Failed to send data export mail", "export_id", requested.ID, "error", err)
+			}
+		})
+		return nil
+	})
+}
+
+func (s *exportService) Download(ctx context.Context, id int64, expires, signature string) ([]byte, error) {
+	if err := s.signer.Verify(downloadPath(id), expires, signature); err != nil {
+		return nil, err
+	}
+	requested, err := s.exports.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !requested.Downloadable(time.Now()) {
+		return nil, domain.NotFound("export is not available")
+	}
+	return requested.Archive, nil
+}
+
+func downloadPath(id int64) string {
+	return "/user/export/" + strconv.FormatInt(id, 10) + "/download"
+}
+
+// exportedProfile 은 내보내기용 프로필. 비밀번호 해시는 넣지 않는다
+type exportedProfile struct {
+	ID         uint      `json:"id"`
+	Email      string    `json:"email"`
+	Name       string    `json:"name"`
+	ProfileUrl string    `json:"profile_url,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (s *exportService) archive(ctx context.Context, u *user.User) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	write := func(name string, data interface{}) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+
+	err := write("profile.json", exportedProfile{
+		ID:         u.ID,
+		Email:      u.Email,
+		Name:       u.Name,
+		ProfileUrl: u.ProfileUrl,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, exporter := range s.exporters {
+		name, data, err := exporter.ExportUserData(ctx, u.ID)
+		if err != nil {
+			return nil, fmt.Errorf("exporter %T: %w", exporter, err)
+		}
+		if err := write(name, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/auth"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/export"
+	"module.resume/internal/domain/user"
+)
+
+// fakeExportRepository 는 내보내기를 메모리에 저장한다
+type fakeExportRepository struct {
+	exports map[int64]*export.Export
+}
+
+func (f *fakeExportRepository) Create(ctx context.Context, e *export.Export) error {
+	e.ID = int64(len(f.exports) + 1)
+	f.exports[e.ID] = e
+	return nil
+}
+
+func (f *fakeExportRepository) Find(ctx context.Context, id int64) (*export.Export, error) {
+	e, ok := f.exports[id]
+	if !ok {
+		return nil, domain.NotFound("export not found")
+	}
+	return e, nil
+}
+
+func (f *fakeExportRepository) FindPending(ctx context.Context, userID uint) (*export.Export, error) {
+	for _, e := range f.exports {
+		if e.UserID == userID && e.Status == export.StatusPending {
+			return e, nil
+		}
+	}
+	return nil, domain.NotFound("export not found")
+}
+
+func (f *fakeExportRepository) MarkReady(ctx context.Context, id int64, archive []byte, expiresAt time.Time) error {
+	e := f.exports[id]
+	e.Status, e.Archive, e.ExpiresAt = export.StatusReady, archive, &expiresAt
+	return nil
+}
+
+func (f *fakeExportRepository) MarkFailed(ctx context.Context, id int64, reason string) error {
+	e := f.exports[id]
+	e.Status, e.Error = export.StatusFailed, reason
+	return nil
+}
+
+type fakeJobQueue struct{ tasks []Task }
+
+func (f *fakeJobQueue) Enqueue(ctx context.Context, task Task) error {
+	f.tasks = append(f.tasks, task)
+	return nil
+}
+
+type fakeMailer struct {
+	sent []Mail
+	// inTx 는 트랜잭션 안에서 보낸 메일이 있었는지
+	inTx bool
+}
+
+func (f *fakeMailer) Send(ctx context.Context, mail Mail) error {
+	f.sent = append(f.sent, mail)
+	f.inTx = f.inTx || InTransaction(ctx)
+	return nil
+}
+
+// hookTxManager 는 실제 TxManager 처럼 fn 이 성공한 뒤에 커밋 뒤 훅을 실행한다
+type hookTxManager struct{}
+
+func (hookTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, commit := WithAfterCommitHooks(ctx)
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
+
+type staticExporter struct{}
+
+func (staticExporter) ExportUserData(ctx context.Context, userID uint) (string, interface{}, error) {
+	return "events.json", []string{"user.registered"}, nil
+}
+
+func TestExportService(t *testing.T) {
+	ctx := context.Background()
+	email := "test@example.com"
+	stored := &user.User{ID: 7, Email: email, Name: "Test"}
+	stored.SetPasswordHash("secret-hash")
+
+	mockRepo := new(MockUserRepository)
+	mockRepo.On("FindByEmail", mock.Anything, email).Return(stored, nil)
+	mockRepo.On("FindByID", mock.Anything, stored.ID).Return(stored, nil)
+	exports := &fakeExportRepository{exports: map[int64]*export.Export{}}
+	queue, mailer := &fakeJobQueue{}, &fakeMailer{}
+	service := NewExportService(mockRepo, exports, hookTxManager{}, queue, mailer,
+		auth.NewURLSigner("key"), "https://api.example.com", time.Hour, staticExporter{})
+
+	requested, err := service.Request(ctx, stored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, export.StatusPending, requested.Status)
+	require.Len(t, queue.tasks, 1)
+	assert.Equal(t, TaskBuildExport, queue.tasks[0].Kind)
+
+	t.Run("pending export is reused", func(t *testing.T) {
+		again, err := service.Request(ctx, stored.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, requested.ID, again.ID)
+		assert.Len(t, queue.tasks, 1)
+	})
+
+	t.Run("build mails a link that downloads the archive", func(t *testing.T) {
+		require.NoError(t, service.Build(ctx, queue.tasks[0].Payload.(BuildExportPayload)))
+		require.Len(t, mailer.sent, 1)
+		assert.Equal(t, email, mailer.sent[0].To)
+		// 커밋된 뒤에 보낸다
+		assert.False(t, mailer.inTx)
+
+		link := mailer.sent[0].Body[strings.Index(mailer.sent[0].Body, "https://"):]
+		parsed, err := url.Parse(strings.TrimSpace(link))
+		require.NoError(t, err)
+		archive, err := service.Download(ctx, requested.ID, parsed.Query().Get("expires"), parsed.Query().Get("signature"))
+		require.NoError(t, err)
+
+		files := readZip(t, archive)
+		assert.Contains(t, files["profile.json"], `"email": "test@example.com"`)
+		assert.NotContains(t, files["profile.json"], "secret-hash")
+		assert.Contains(t, files["events.json"], "user.registered")
+	})
+
+	t.Run("build is idempotent", func(t *testing.T) {
+		require.NoError(t, service.Build(ctx, queue.tasks[0].Payload.(BuildExportPayload)))
+		assert.Len(t, mailer.sent, 1)
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		_, err := service.Download(ctx, requested.ID, "9999999999", "forged")
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+}
+
+func readZip(t *testing.T, archive []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	return files
+}
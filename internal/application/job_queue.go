@@ -0,0 +1,22 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// Task 는 요청 처리 시간 안에 끝내기 어려운 작업(메일 발송, PDF 생성 등)을 나타낸다
+type Task struct {
+	Kind    string
+	Payload interface{}
+	// IdempotencyKey 가 같은 작업은 한 번만 등록된다
+	IdempotencyKey string
+	// RunAt 이 비어 있으면 바로 실행한다
+	RunAt time.Time
+	// MaxAttempts 가 0 이면 큐의 기본값을 쓴다
+	MaxAttempts int
+}
+
+type JobQueue interface {
+	Enqueue(ctx context.Context, task Task) error
+}
@@ -0,0 +1,14 @@
+package application
+
+import "context"
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer 는 사용자에게 알림 메일을 보낸다
+type Mailer interface {
+	Send(ctx context.Context, mail Mail) error
+}
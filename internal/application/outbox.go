@@ -0,0 +1,13 @@
+package application
+
+import (
+	"context"
+
+	"module.resume/internal/domain"
+)
+
+// Outbox 는 도메인 이벤트를 상태 변경과 같은 트랜잭션에 기록한다
+// 기록된 이벤트는 배치의 relay 가 외부로 전달한다
+type Outbox interface {
+	Append(ctx context.Context, events ...domain.Event) error
+}
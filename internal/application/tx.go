@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"sync"
+)
+
+// TxManager 는 여러 저장소 호출을 하나의 작업 단위(unit of work)로 묶는다
+// fn 에 넘어가는 ctx 에 트랜잭션이 담기고, 저장소는 ctx 에서 트랜잭션을 찾아 사용한다
+// 이미 트랜잭션 안이면 새로 시작하지 않고 바깥 트랜잭션에 합류한다
+// fn 이 에러를 돌려주면 모든 변경이 롤백된다
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type afterCommitKey struct{}
+
+type afterCommitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// AfterCommit 은 ctx 의 트랜잭션이 커밋된 뒤에 fn 을 실행한다. 롤백되면 실행하지 않는다
+// 트랜잭션 밖에서 호출하면 바로 실행한다. 캐시 무효화처럼 커밋 전에 하면 안 되는 일에 쓴다
+func AfterCommit(ctx context.Context, fn func()) {
+	h, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok {
+		fn()
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, fn)
+}
+
+// InTransaction 은 ctx 가 TxManager 의 트랜잭션 안인지 알려준다
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	return ok
+}
+
+// WithAfterCommitHooks 는 TxManager 구현이 트랜잭션을 시작할 때 호출한다
+// 커밋에 성공하면 돌려받은 run 을 호출해 등록된 fn 을 실행한다
+func WithAfterCommitHooks(ctx context.Context) (context.Context, func()) {
+	h := &afterCommitHooks{}
+	run := func() {
+		h.mu.Lock()
+		hooks := h.hooks
+		h.hooks = nil
+		h.mu.Unlock()
+		for _, fn := range hooks {
+			fn()
+		}
+	}
+	return context.WithValue(ctx, afterCommitKey{}, h), run
+}
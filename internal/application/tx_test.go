@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAfterCommit(t *testing.T) {
+	t.Run("outside transaction runs immediately", func(t *testing.T) {
+		ran := false
+		AfterCommit(context.Background(), func() { ran = true })
+
+		assert.True(t, ran)
+		assert.False(t, InTransaction(context.Background()))
+	})
+
+	t.Run("inside transaction waits for commit", func(t *testing.T) {
+		ctx, run := WithAfterCommitHooks(context.Background())
+		var order []int
+		AfterCommit(ctx, func() { order = append(order, 1) })
+		AfterCommit(ctx, func() { order = append(order, 2) })
+
+		assert.True(t, InTransaction(ctx))
+		assert.Empty(t, order)
+
+		run()
+		assert.Equal(t, []int{1, 2}, order)
+
+		// 두 번 실행되지 않는다
+		run()
+		assert.Equal(t, []int{1, 2}, order)
+	})
+}
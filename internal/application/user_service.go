@@ -2,34 +2,154 @@ package application
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"module.resume/internal/domain"
 	"module.resume/internal/domain/user"
 )
 
 type UserService interface {
+	Get(ctx context.Context, id uint) (*user.User, error)
+	Patch(ctx context.Context, id uint, patch ProfilePatch) (*user.User, error)
 	Save(context context.Context, user *user.User) (uint, error)
-	Update(context context.Context, user *user.User) (uint, error)
-	Delete(context context.Context, user *user.User) error
+	UpdatePassword(ctx context.Context, userID uint, currentPassword, newPassword string) error
+	Delete(ctx context.Context, userID uint, password string) error
+	CancelDeletion(ctx context.Context, email, password string) error
 }
 
+// ProfilePatch 는 바꿀 프로필 필드만 담는다. nil 인 필드는 그대로 둔다
+type ProfilePatch struct {
+	Name       *string
+	ProfileUrl *string
+}
+
+var errInvalidPassword = domain.Unauthorized("current password is incorrect")
+
 type userService struct {
-	repo user.Repository
+	repo   user.Repository
+	tx     TxManager
+	outbox Outbox
+	// deletionGrace 동안은 탈퇴를 취소할 수 있다
+	deletionGrace time.Duration
 }
 
-func NewUserService(repo user.Repository) UserService {
+func NewUserService(repo user.Repository, tx TxManager, outbox Outbox, deletionGrace time.Duration) UserService {
 	return &userService{
 		repo,
+		tx,
+		outbox,
+		deletionGrace,
+	}
+}
+
+// Save 는 가입 완료 이벤트를 사용자 저장과 같은 트랜잭션으로 outbox 에 남긴다
+func (service *userService) Save(ctx context.Context, user *user.User) (uint, error) {
+	var id uint
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		id, err = service.repo.Save(ctx, user)
+		if err != nil {
+			return err
+		}
+		user.ID = id
+		user.CompleteRegistration()
+		return service.outbox.Append(ctx, user.PullEvents()...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (service *userService) Get(ctx context.Context, id uint) (*user.User, error) {
+	return service.repo.FindByID(ctx, id)
+}
+
+// Patch 는 읽고 고쳐서 쓰는 사이에 다른 변경이 끼어들지 않도록 한 트랜잭션으로 처리한다
+func (service *userService) Patch(ctx context.Context, id uint, patch ProfilePatch) (*user.User, error) {
+	var patched *user.User
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := service.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if patch.Name != nil {
+			stored.Name = *patch.Name
+		}
+		if patch.ProfileUrl != nil {
+			stored.ProfileUrl = *patch.ProfileUrl
+		}
+		if _, err := service.repo.Update(ctx, stored); err != nil {
+			return err
+		}
+		patched = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return patched, nil
 }
 
-func (service *userService) Save(context context.Context, user *user.User) (uint, error) {
-	return service.repo.Save(context, user)
+// UpdatePassword 는 비밀번호 변경과 기존 토큰 무효화를 한 트랜잭션으로 처리한다
+func (service *userService) UpdatePassword(ctx context.Context, userID uint, currentPassword, newPassword string) error {
+	return service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := service.repo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !stored.CheckPassword(currentPassword) {
+			return errInvalidPassword
+		}
+		if err := stored.ChangePassword(newPassword); err != nil {
+			return err
+		}
+		if err := service.repo.UpdatePassword(ctx, stored); err != nil {
+			return err
+		}
+		return service.outbox.Append(ctx, stored.PullEvents()...)
+	})
 }
 
-func (service *userService) Update(context context.Context, user *user.User) (uint, error) {
-	return service.repo.Update(context, user)
+// Delete 는 비밀번호를 다시 확인한 뒤 계정을 탈퇴 처리하고 모든 토큰을 무효로 만든다
+// 개인정보와 딸린 데이터는 유예 기간이 지난 뒤 배치가 지운다
+func (service *userService) Delete(ctx context.Context, userID uint, password string) error {
+	return service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := service.repo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !stored.CheckPassword(password) {
+			return errInvalidPassword
+		}
+		stored.Delete()
+		if err := service.repo.Delete(ctx, stored); err != nil {
+			return err
+		}
+		return service.outbox.Append(ctx, stored.PullEvents()...)
+	})
 }
 
-func (service *userService) Delete(context context.Context, user *user.User) error {
-	return service.repo.Delete(context, user.ID)
+// CancelDeletion 은 유예 기간 안의 탈퇴를 취소한다. 토큰이 모두 무효라서 email 과 비밀번호로 확인한다
+func (service *userService) CancelDeletion(ctx context.Context, email, password string) error {
+	return service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := service.repo.FindDeletedByEmail(ctx, email)
+		if errors.Is(err, domain.ErrNotFound) {
+			return errInvalidCredentials
+		}
+		if err != nil {
+			return err
+		}
+		if !stored.CheckPassword(password) {
+			return errInvalidCredentials
+		}
+		if err := stored.CancelDeletion(service.deletionGrace); err != nil {
+			return err
+		}
+		if err := service.repo.Restore(ctx, stored); err != nil {
+			return err
+		}
+		return service.outbox.Append(ctx, stored.PullEvents()...)
+	})
 }
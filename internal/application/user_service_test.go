@@ -4,16 +4,27 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"module.resume/internal/domain"
 	"module.resume/internal/domain/user"
+	"module.resume/internal/util"
 )
 
 type MockUserRepository struct {
 	mock.Mock
 }
 
+func (m *MockUserRepository) FindByID(ctx context.Context, id uint) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
 func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
 	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
@@ -22,6 +33,14 @@ func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*us
 	return args.Get(0).(*user.User), args.Error(1)
 }
 
+func (m *MockUserRepository) FindCredentials(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
 func (m *MockUserRepository) Save(ctx context.Context, u *user.User) (uint, error) {
 	args := m.Called(ctx, u)
 	return uint(args.Int(0)), args.Error(1)
@@ -32,25 +51,99 @@ func (m *MockUserRepository) Update(ctx context.Context, u *user.User) (uint, er
 	return uint(args.Int(0)), args.Error(1)
 }
 
-func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
-	args := m.Called(ctx, id)
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateEmail(ctx context.Context, u *user.User, oldEmail string) error {
+	args := m.Called(ctx, u, oldEmail)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateAvatar(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindDeletedByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Restore(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+const testDeletionGrace = 14 * 24 * time.Hour
+
+func newStoredUser(t *testing.T, email, password string) *user.User {
+	hashed, err := util.HashPassword(password)
+	assert.NoError(t, err)
+	u := &user.User{ID: 1, Email: email}
+	u.SetPasswordHash(hashed)
+	return u
+}
+
+// fakeTxManager 는 트랜잭션 없이 fn 을 그대로 실행한다
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type MockOutbox struct {
+	mock.Mock
+}
+
+func (m *MockOutbox) Append(ctx context.Context, events ...domain.Event) error {
+	args := m.Called(ctx, events)
 	return args.Error(0)
 }
 
 func TestUserService_Save(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	userService := NewUserService(mockRepo)
+	mockOutbox := new(MockOutbox)
+	userService := NewUserService(mockRepo, fakeTxManager{}, mockOutbox, testDeletionGrace)
 	ctx := context.Background()
 	testUser := &user.User{Email: "test@example.com", Password: "password"}
 
 	t.Run("success", func(t *testing.T) {
 		mockRepo.On("Save", ctx, testUser).Return(1, nil).Once()
+		mockOutbox.On("Append", ctx, mock.MatchedBy(func(events []domain.Event) bool {
+			if len(events) != 1 {
+				return false
+			}
+			registered, ok := events[0].(user.Registered)
+			return ok && registered.UserID == 1 && registered.Email == "test@example.com"
+		})).Return(nil).Once()
 
 		id, err := userService.Save(ctx, testUser)
 
 		assert.NoError(t, err)
 		assert.Equal(t, uint(1), id)
 		mockRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("outbox error", func(t *testing.T) {
+		mockRepo.On("Save", ctx, testUser).Return(2, nil).Once()
+		mockOutbox.On("Append", ctx, mock.Anything).Return(errors.New("outbox down")).Once()
+
+		id, err := userService.Save(ctx, testUser)
+
+		assert.EqualError(t, err, "outbox down")
+		assert.Equal(t, uint(0), id)
+		mockOutbox.AssertExpectations(t)
 	})
 
 	t.Run("error", func(t *testing.T) {
@@ -65,56 +158,193 @@ func TestUserService_Save(t *testing.T) {
 	})
 }
 
-func TestUserService_Update(t *testing.T) {
+func TestUserService_Patch(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	userService := NewUserService(mockRepo)
+	userService := NewUserService(mockRepo, fakeTxManager{}, new(MockOutbox), testDeletionGrace)
 	ctx := context.Background()
-	testUser := &user.User{ID: 1, Email: "update@example.com"}
+	stored := func() *user.User {
+		return &user.User{ID: 1, Email: "patch@example.com", Name: "Before", ProfileUrl: "https://example.com/me.png"}
+	}
+	name, empty := "After", ""
 
-	t.Run("success", func(t *testing.T) {
-		mockRepo.On("Update", ctx, testUser).Return(1, nil).Once()
+	t.Run("omitted fields are kept", func(t *testing.T) {
+		mockRepo.On("FindByID", ctx, uint(1)).Return(stored(), nil).Once()
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *user.User) bool {
+			return u.Name == "After" && u.ProfileUrl == "https://example.com/me.png" && u.Email == "patch@example.com"
+		})).Return(1, nil).Once()
 
-		id, err := userService.Update(ctx, testUser)
+		patched, err := userService.Patch(ctx, 1, ProfilePatch{Name: &name})
 
 		assert.NoError(t, err)
-		assert.Equal(t, uint(1), id)
+		assert.Equal(t, "After", patched.Name)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("error", func(t *testing.T) {
-		mockRepo.On("Update", ctx, testUser).Return(0, errors.New("update failed")).Once()
+	t.Run("null clears the field", func(t *testing.T) {
+		mockRepo.On("FindByID", ctx, uint(1)).Return(stored(), nil).Once()
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *user.User) bool {
+			return u.Name == "Before" && u.ProfileUrl == ""
+		})).Return(1, nil).Once()
 
-		id, err := userService.Update(ctx, testUser)
+		_, err := userService.Patch(ctx, 1, ProfilePatch{ProfileUrl: &empty})
 
-		assert.Error(t, err)
-		assert.Equal(t, "update failed", err.Error())
-		assert.Equal(t, uint(0), id)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo.On("FindByID", ctx, uint(2)).Return(nil, domain.NotFound("user not found")).Once()
+
+		_, err := userService.Patch(ctx, 2, ProfilePatch{Name: &name})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
 		mockRepo.AssertExpectations(t)
 	})
 }
 
 func TestUserService_Delete(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	userService := NewUserService(mockRepo)
+	mockOutbox := new(MockOutbox)
+	userService := NewUserService(mockRepo, fakeTxManager{}, mockOutbox, testDeletionGrace)
 	ctx := context.Background()
-	testUser := &user.User{ID: 1}
+	email := "test@example.com"
 
 	t.Run("success", func(t *testing.T) {
-		mockRepo.On("Delete", ctx, testUser.ID).Return(nil).Once()
+		stored := newStoredUser(t, email, "current-password")
+		mockRepo.On("FindByID", ctx, uint(1)).Return(stored, nil).Once()
+		mockRepo.On("Delete", ctx, stored).Return(nil).Once()
+		mockOutbox.On("Append", ctx, mock.MatchedBy(func(events []domain.Event) bool {
+			return len(events) == 1 && events[0].EventName() == "user.deleted"
+		})).Return(nil).Once()
 
-		err := userService.Delete(ctx, testUser)
+		err := userService.Delete(ctx, 1, "current-password")
 
 		assert.NoError(t, err)
+		assert.NotNil(t, stored.DeletedAt)
+		assert.True(t, stored.TokenRevoked(time.Now().Add(-time.Minute)))
+		mockRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		stored := newStoredUser(t, email, "current-password")
+		mockRepo.On("FindByID", ctx, uint(1)).Return(stored, nil).Once()
+
+		err := userService.Delete(ctx, 1, "wrong-password")
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Nil(t, stored.DeletedAt)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockRepo.On("Delete", ctx, testUser.ID).Return(errors.New("delete failed")).Once()
+		stored := newStoredUser(t, email, "current-password")
+		mockRepo.On("FindByID", ctx, uint(1)).Return(stored, nil).Once()
+		mockRepo.On("Delete", ctx, stored).Return(errors.New("delete failed")).Once()
 
-		err := userService.Delete(ctx, testUser)
+		err := userService.Delete(ctx, 1, "current-password")
 
-		assert.Error(t, err)
-		assert.Equal(t, "delete failed", err.Error())
+		assert.EqualError(t, err, "delete failed")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_CancelDeletion(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockOutbox := new(MockOutbox)
+	userService := NewUserService(mockRepo, fakeTxManager{}, mockOutbox, testDeletionGrace)
+	ctx := context.Background()
+	email := "test@example.com"
+
+	deletedUser := func(t *testing.T, deletedAt time.Time) *user.User {
+		u := newStoredUser(t, email, "current-password")
+		u.DeletedAt = &deletedAt
+		return u
+	}
+
+	t.Run("within grace period", func(t *testing.T) {
+		stored := deletedUser(t, time.Now().Add(-time.Hour))
+		mockRepo.On("FindDeletedByEmail", ctx, email).Return(stored, nil).Once()
+		mockRepo.On("Restore", ctx, stored).Return(nil).Once()
+		mockOutbox.On("Append", ctx, mock.MatchedBy(func(events []domain.Event) bool {
+			return len(events) == 1 && events[0].EventName() == "user.deletion_cancelled"
+		})).Return(nil).Once()
+
+		err := userService.CancelDeletion(ctx, email, "current-password")
+
+		assert.NoError(t, err)
+		assert.Nil(t, stored.DeletedAt)
+		mockRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("grace period is over", func(t *testing.T) {
+		stored := deletedUser(t, time.Now().Add(-testDeletionGrace-time.Hour))
+		mockRepo.On("FindDeletedByEmail", ctx, email).Return(stored, nil).Once()
+
+		err := userService.CancelDeletion(ctx, email, "current-password")
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password and unknown email look the same", func(t *testing.T) {
+		mockRepo.On("FindDeletedByEmail", ctx, email).Return(deletedUser(t, time.Now()), nil).Once()
+		wrongPassword := userService.CancelDeletion(ctx, email, "wrong-password")
+
+		mockRepo.On("FindDeletedByEmail", ctx, "nobody@example.com").Return(nil, domain.NotFound("user not found")).Once()
+		unknown := userService.CancelDeletion(ctx, "nobody@example.com", "current-password")
+
+		assert.ErrorIs(t, wrongPassword, domain.ErrUnauthorized)
+		assert.Equal(t, wrongPassword, unknown)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_UpdatePassword(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockOutbox := new(MockOutbox)
+	userService := NewUserService(mockRepo, fakeTxManager{}, mockOutbox, testDeletionGrace)
+	ctx := context.Background()
+	email := "test@example.com"
+
+	t.Run("success", func(t *testing.T) {
+		stored := newStoredUser(t, email, "current-password")
+		mockRepo.On("FindByID", ctx, uint(1)).Return(stored, nil).Once()
+		mockRepo.On("UpdatePassword", ctx, stored).Return(nil).Once()
+		mockOutbox.On("Append", ctx, mock.MatchedBy(func(events []domain.Event) bool {
+			return len(events) == 1 && events[0].EventName() == "user.password_changed"
+		})).Return(nil).Once()
+
+		err := userService.UpdatePassword(ctx, 1, "current-password", "new-password-123")
+
+		assert.NoError(t, err)
+		assert.True(t, stored.CheckPassword("new-password-123"))
+		assert.NotNil(t, stored.TokensInvalidBefore)
+		mockRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		stored := newStoredUser(t, email, "current-password")
+		mockRepo.On("FindByID", ctx, uint(1)).Return(stored, nil).Once()
+
+		err := userService.UpdatePassword(ctx, 1, "wrong-password", "new-password-123")
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.True(t, stored.CheckPassword("current-password"))
+		mockRepo.AssertNotCalled(t, "UpdatePassword", ctx, stored)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		stored := newStoredUser(t, email, "current-password")
+		mockRepo.On("FindByID", ctx, uint(1)).Return(stored, nil).Once()
+		mockRepo.On("UpdatePassword", ctx, stored).Return(errors.New("update failed")).Once()
+
+		err := userService.UpdatePassword(ctx, 1, "current-password", "new-password-123")
+
+		assert.EqualError(t, err, "update failed")
 		mockRepo.AssertExpectations(t)
 	})
 }
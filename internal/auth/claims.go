@@ -1,9 +1,17 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// iat 가 초 단위면 비밀번호를 바꾼 그 초에 발급된 토큰을 무효화 전후로 구분할 수 없어서 밀리초까지 쓴다
+func init() {
+	jwt.TimePrecision = time.Millisecond
+}
+
 type Claims struct {
 	jwt.RegisteredClaims
+	UserID uint `json:"uid,omitempty"`
 }
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"time"
+
+	"module.resume/internal/domain"
+)
+
+var errInvalidLink = domain.Forbidden("link is invalid or has expired")
+
+// URLSigner 는 로그인 없이 여는 링크(메일로 보내는 다운로드 링크 등)에 만료 시각과 서명을 붙인다
+type URLSigner struct {
+	key []byte
+}
+
+func NewURLSigner(key string) *URLSigner {
+	return &URLSigner{key: []byte(key)}
+}
+
+// Sign 은 path 에 expires, signature 쿼리를 붙여 돌려준다
+func (s *URLSigner) Sign(path string, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	query := url.Values{"expires": {exp}, "signature": {s.mac(path, exp)}}
+	return path + "?" + query.Encode()
+}
+
+// Verify 는 Sign 이 만든 expires, signature 가 path 와 맞고 아직 만료되지 않았는지 확인한다
+func (s *URLSigner) Verify(path, expires, signature string) error {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return errInvalidLink
+	}
+	if !hmac.Equal([]byte(s.mac(path, expires)), []byte(signature)) {
+		return errInvalidLink
+	}
+	return nil
+}
+
+func (s *URLSigner) mac(path, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(path + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+)
+
+func TestURLSigner(t *testing.T) {
+	signer := NewURLSigner("secret")
+	path := "/user/export/1/download"
+
+	parse := func(t *testing.T, signed string) url.Values {
+		_, rawQuery, ok := strings.Cut(signed, "?")
+		require.True(t, ok)
+		query, err := url.ParseQuery(rawQuery)
+		require.NoError(t, err)
+		return query
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		q := parse(t, signer.Sign(path, time.Now().Add(time.Hour)))
+
+		assert.NoError(t, signer.Verify(path, q.Get("expires"), q.Get("signature")))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		q := parse(t, signer.Sign(path, time.Now().Add(-time.Second)))
+
+		assert.ErrorIs(t, signer.Verify(path, q.Get("expires"), q.Get("signature")), domain.ErrForbidden)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		q := parse(t, signer.Sign(path, time.Now().Add(time.Hour)))
+
+		assert.Error(t, signer.Verify("/user/export/2/download", q.Get("expires"), q.Get("signature")))
+		assert.Error(t, signer.Verify(path, q.Get("expires")+"0", q.Get("signature")))
+		assert.Error(t, NewURLSigner("other").Verify(path, q.Get("expires"), q.Get("signature")))
+	})
+}
@@ -0,0 +1,121 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/user"
+)
+
+type DeletedUserPurger interface {
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PurgeDeletedUsersJob 은 retention 보다 오래 전에 탈퇴한 계정을 완전히 삭제한다
+func PurgeDeletedUsersJob(users DeletedUserPurger, schedule string, retention time.Duration, log *slog.Logger) Job {
+	return Job{
+		Name:     "purge-deleted-users",
+		Schedule: schedule,
+		Run: func(ctx context.Context) error {
+			purged, err := users.PurgeDeleted(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			log.Info("Purged deleted users", "count", purged)
+			return nil
+		},
+	}
+}
+
+type ExpiredExportPurger interface {
+	PurgeExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PurgeExpiredExportsJob 은 내려받을 기간이 지난 데이터 내보내기 ZIP 을 지운다
+func PurgeExpiredExportsJob(exports ExpiredExportPurger, schedule string, log *slog.Logger) Job {
+	return Job{
+		Name:     "purge-expired-exports",
+		Schedule: schedule,
+		Run: func(ctx context.Context) error {
+			purged, err := exports.PurgeExpired(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+			log.Info("Purged expired exports", "count", purged)
+			return nil
+		},
+	}
+}
+
+type DeletedUserAnonymizer interface {
+	FindAnonymizable(ctx context.Context, before time.Time, limit int) ([]uint, error)
+	// LockAnonymizable 는 아직 익명화할 수 있는 계정이면 행을 잠그고, 아니면 NotFound 를 돌려준다
+	LockAnonymizable(ctx context.Context, id uint) error
+	Anonymize(ctx context.Context, id uint) error
+}
+
+const anonymizeBatchSize = 100
+
+// AnonymizeDeletedUsersJob 은 탈퇴 유예 기간이 지난 계정의 개인정보와 딸린 데이터를 지운다
+// 계정마다 한 트랜잭션으로 erasers, 익명화, user.erased 이벤트 기록을 함께 처리한다
+func AnonymizeDeletedUsersJob(users DeletedUserAnonymizer, tx application.TxManager, outbox application.Outbox,
+	schedule string, gracePeriod time.Duration, log *slog.Logger, erasers ...application.UserDataEraser) Job {
+	return Job{
+		Name:     "anonymize-deleted-users",
+		Schedule: schedule,
+		Run: func(ctx context.Context) error {
+			before := time.Now().Add(-gracePeriod)
+			var anonymized int
+			for {
+				ids, err := users.FindAnonymizable(ctx, before, anonymizeBatchSize)
+				if err != nil {
+					return err
+				}
+				// 실패한 계정은 다음 실행에서 다시 시도한다. 같은 계정을 계속 다시 읽지 않도록 이번 실행은 멈춘다
+				var errs []error
+				for _, id := range ids {
+					err := anonymizeUser(ctx, users, tx, outbox, id, erasers)
+					// 목록을 읽은 뒤 탈퇴를 취소한 계정이다
+					if errors.Is(err, domain.ErrNotFound) {
+						log.Info("Skipped user that is no longer anonymizable", "user_id", id)
+						continue
+					}
+					if err != nil {
+						log.Error("Failed to anonymize deleted user", "user_id", id, "error", err)
+						errs = append(errs, fmt.Errorf("user %d: %w", id, err))
+						continue
+					}
+					anonymized++
+				}
+				if len(errs) > 0 || len(ids) < anonymizeBatchSize {
+					log.Info("Anonymized deleted users", "count", anonymized)
+					return errors.Join(errs...)
+				}
+			}
+		},
+	}
+}
+
+func anonymizeUser(ctx context.Context, users DeletedUserAnonymizer, tx application.TxManager, outbox application.Outbox,
+	id uint, erasers []application.UserDataEraser) error {
+	return tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 되돌릴 수 없는 eraser(파일 삭제 등)가 돌기 전에 행을 잠가 탈퇴 취소와 겹치지 않게 한다
+		if err := users.LockAnonymizable(ctx, id); err != nil {
+			return err
+		}
+		for _, eraser := range erasers {
+			if err := eraser.EraseUserData(ctx, id); err != nil {
+				return err
+			}
+		}
+		if err := users.Anonymize(ctx, id); err != nil {
+			return err
+		}
+		return outbox.Append(ctx, user.Erased{UserID: id, At: time.Now()})
+	})
+}
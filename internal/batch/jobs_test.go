@@ -0,0 +1,93 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"module.resume/internal/domain"
+)
+
+type fakeAnonymizer struct {
+	pending    []uint
+	anonymized []uint
+	fail       map[uint]error
+	// restored 는 목록을 읽은 뒤 탈퇴를 취소한 계정
+	restored map[uint]bool
+}
+
+func (f *fakeAnonymizer) FindAnonymizable(ctx context.Context, before time.Time, limit int) ([]uint, error) {
+	return slices.Clone(f.pending[:min(limit, len(f.pending))]), nil
+}
+
+func (f *fakeAnonymizer) LockAnonymizable(ctx context.Context, id uint) error {
+	if f.restored[id] {
+		f.pending = slices.DeleteFunc(f.pending, func(pending uint) bool { return pending == id })
+		return domain.NotFound("user not found")
+	}
+	return nil
+}
+
+func (f *fakeAnonymizer) Anonymize(ctx context.Context, id uint) error {
+	if err := f.fail[id]; err != nil {
+		return err
+	}
+	f.anonymized = append(f.anonymized, id)
+	f.pending = slices.DeleteFunc(f.pending, func(pending uint) bool { return pending == id })
+	return nil
+}
+
+type fakeEraser struct{ erased []uint }
+
+func (f *fakeEraser) EraseUserData(ctx context.Context, userID uint) error {
+	f.erased = append(f.erased, userID)
+	return nil
+}
+
+type fakeOutbox struct{ events []domain.Event }
+
+func (f *fakeOutbox) Append(ctx context.Context, events ...domain.Event) error {
+	f.events = append(f.events, events...)
+	return nil
+}
+
+func TestAnonymizeDeletedUsersJob(t *testing.T) {
+	t.Run("erases and anonymizes every pending user", func(t *testing.T) {
+		users := &fakeAnonymizer{}
+		for id := uint(1); id <= anonymizeBatchSize+1; id++ {
+			users.pending = append(users.pending, id)
+		}
+		eraser, outbox := &fakeEraser{}, &fakeOutbox{}
+		job := AnonymizeDeletedUsersJob(users, directTx{}, outbox, "@daily", time.Hour, discard, eraser)
+
+		assert.NoError(t, job.Run(context.Background()))
+		assert.Len(t, users.anonymized, anonymizeBatchSize+1)
+		assert.Equal(t, users.anonymized, eraser.erased)
+		assert.Len(t, outbox.events, anonymizeBatchSize+1)
+		assert.Equal(t, "user.erased", outbox.events[0].EventName())
+	})
+
+	t.Run("a failing user does not stop the others", func(t *testing.T) {
+		users := &fakeAnonymizer{pending: []uint{1, 2, 3}, fail: map[uint]error{2: errors.New("boom")}}
+		job := AnonymizeDeletedUsersJob(users, directTx{}, &fakeOutbox{}, "@daily", time.Hour, discard)
+
+		err := job.Run(context.Background())
+
+		assert.ErrorContains(t, err, "user 2: boom")
+		assert.Equal(t, []uint{1, 3}, users.anonymized)
+	})
+
+	t.Run("restored users are skipped before erasers run", func(t *testing.T) {
+		users := &fakeAnonymizer{pending: []uint{1, 2}, restored: map[uint]bool{2: true}}
+		eraser, outbox := &fakeEraser{}, &fakeOutbox{}
+		job := AnonymizeDeletedUsersJob(users, directTx{}, outbox, "@daily", time.Hour, discard, eraser)
+
+		assert.NoError(t, job.Run(context.Background()))
+		assert.Equal(t, []uint{1}, users.anonymized)
+		assert.Equal(t, []uint{1}, eraser.erased)
+		assert.Len(t, outbox.events, 1)
+	})
+}
@@ -0,0 +1,205 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"module.resume/internal/application"
+	"module.resume/internal/infrastructure/persistence/gorm"
+	"module.resume/internal/infrastructure/queue"
+)
+
+// Sink 는 outbox 이벤트를 받는 곳. 같은 이벤트가 두 번 올 수 있으므로 메시지 ID 로 중복을 걸러야 한다
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, msg gorm.OutboxMessage) error
+}
+
+type OutboxStore interface {
+	ClaimUnpublished(ctx context.Context, limit int, claimedUntil time.Time) ([]gorm.OutboxMessage, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, cause error, nextAttemptAt time.Time) error
+	MarkDead(ctx context.Context, id int64, cause error) error
+}
+
+// claimLease 동안은 가져간 메시지를 다른 relay 가 읽지 않는다
+// 배치 하나를 전달하는 데 걸리는 시간보다 넉넉해야 하고, relay 가 죽으면 이 시간이 지난 뒤 다시 전달된다
+const claimLease = 5 * time.Minute
+
+// OutboxRelay 는 outbox 에 쌓인 이벤트를 모든 sink 에 전달한다 (at-least-once)
+type OutboxRelay struct {
+	store        OutboxStore
+	tx           application.TxManager
+	sinks        []Sink
+	log          *slog.Logger
+	batchSize    int
+	pollInterval time.Duration
+	// maxAttempts 번 실패한 이벤트는 dead 로 옮겨 더 이상 전달하지 않는다
+	maxAttempts int
+}
+
+func NewOutboxRelay(store OutboxStore, tx application.TxManager, log *slog.Logger, batchSize int, pollInterval time.Duration, maxAttempts int, sinks ...Sink) *OutboxRelay {
+	return &OutboxRelay{
+		store:        store,
+		tx:           tx,
+		sinks:        sinks,
+		log:          log,
+		batchSize:    batchSize,
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+	}
+}
+
+func (r *OutboxRelay) Run(ctx context.Context) {
+	r.log.Info("Outbox relay started", "sinks", len(r.sinks))
+	for ctx.Err() == nil {
+		locked, published, err := r.relayBatch(ctx)
+		if err != nil {
+			r.log.Error("Failed to relay outbox", "error", err)
+		}
+		// 꽉 찬 배치였으면 남은 이벤트가 있을 수 있으니 바로 다시 읽는다
+		// 하나도 전달하지 못했으면 sink 장애일 가능성이 높으므로 poll 간격만큼 쉰다
+		if err == nil && locked == r.batchSize && published > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+// relayBatch 는 메시지를 가져가서(claim) 트랜잭션 밖에서 전달하고, 결과를 짧은 트랜잭션으로 기록한다
+// sink 가 느려도 행 잠금이나 커넥션을 붙잡지 않는다
+// 가져간 메시지 수와 그중 전달한 수를 돌려준다
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, int, error) {
+	messages, err := r.store.ClaimUnpublished(ctx, r.batchSize, time.Now().Add(claimLease))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	results := make([]error, 0, len(messages))
+	for _, msg := range messages {
+		err := r.publish(ctx, msg)
+		// 종료 중이라 실패한 메시지는 기록하지 않는다. claim 이 끝나면 다시 전달된다
+		if err != nil && ctx.Err() != nil {
+			break
+		}
+		results = append(results, err)
+	}
+
+	published := 0
+	// 종료 신호를 받았어도 이미 전달한 결과는 기록한다
+	err = r.tx.WithinTransaction(context.WithoutCancel(ctx), func(ctx context.Context) error {
+		for i, publishErr := range results {
+			msg := messages[i]
+			if publishErr != nil {
+				if err := r.markFailed(ctx, msg, publishErr); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.store.MarkPublished(ctx, msg.ID); err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return len(messages), 0, err
+	}
+	return len(messages), published, nil
+}
+
+func (r *OutboxRelay) markFailed(ctx context.Context, msg gorm.OutboxMessage, cause error) error {
+	attempt := msg.Attempts + 1
+	log := r.log.With("event_id", msg.ID, "event", msg.EventName, "attempt", attempt, "error", cause)
+	if attempt >= r.maxAttempts {
+		log.Error("Event moved to dead letter")
+		return r.store.MarkDead(ctx, msg.ID, cause)
+	}
+	retryIn := queue.Backoff(attempt)
+	log.Warn("Failed to publish event, will retry", "retry_in", retryIn)
+	return r.store.MarkFailed(ctx, msg.ID, cause, time.Now().Add(retryIn))
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, msg gorm.OutboxMessage) error {
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogSink 는 이벤트를 로그로만 남긴다. 다른 sink 가 없을 때의 기본값
+type LogSink struct {
+	log *slog.Logger
+}
+
+func NewLogSink(log *slog.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+func (s *LogSink) Name() string { return "log" }
+
+func (s *LogSink) Publish(ctx context.Context, msg gorm.OutboxMessage) error {
+	s.log.InfoContext(ctx, "Domain event", "event_id", msg.ID, "event", msg.EventName,
+		"aggregate_type", msg.AggregateType, "aggregate_id", msg.AggregateID)
+	return nil
+}
+
+// WebhookSink 는 이벤트를 JSON 으로 POST 한다
+// 받는 쪽은 X-Signature 헤더(본문의 HMAC-SHA256)로 출처를 확인할 수 있다
+type WebhookSink struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+func NewWebhookSink(url, secret string, client *http.Client) *WebhookSink {
+	return &WebhookSink{url: url, secret: []byte(secret), client: client}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Publish(ctx context.Context, msg gorm.OutboxMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", strconv.FormatInt(msg.ID, 10))
+	req.Header.Set("X-Event-Name", msg.EventName)
+	if len(s.secret) > 0 {
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write(body)
+		req.Header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package batch
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/application"
+	"module.resume/internal/infrastructure/persistence/gorm"
+	"module.resume/internal/infrastructure/queue"
+)
+
+type fakeOutboxStore struct {
+	messages  []gorm.OutboxMessage
+	published []int64
+	failed    map[int64]error
+	retryAt   map[int64]time.Time
+	dead      []int64
+	claims    atomic.Int32
+	claimed   time.Time
+}
+
+func newFakeOutboxStore(messages ...gorm.OutboxMessage) *fakeOutboxStore {
+	return &fakeOutboxStore{messages: messages, failed: map[int64]error{}, retryAt: map[int64]time.Time{}}
+}
+
+func (f *fakeOutboxStore) ClaimUnpublished(ctx context.Context, limit int, claimedUntil time.Time) ([]gorm.OutboxMessage, error) {
+	f.claims.Add(1)
+	f.claimed = claimedUntil
+	return f.messages, nil
+}
+
+func (f *fakeOutboxStore) MarkPublished(ctx context.Context, id int64) error {
+	f.published = append(f.published, id)
+	return nil
+}
+
+func (f *fakeOutboxStore) MarkFailed(ctx context.Context, id int64, cause error, nextAttemptAt time.Time) error {
+	f.failed[id] = cause
+	f.retryAt[id] = nextAttemptAt
+	return nil
+}
+
+func (f *fakeOutboxStore) MarkDead(ctx context.Context, id int64, cause error) error {
+	f.failed[id] = cause
+	f.dead = append(f.dead, id)
+	return nil
+}
+
+type directTx struct{}
+
+func (directTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// hookTx 는 실제 TxManager 처럼 ctx 를 트랜잭션 안으로 표시한다
+type hookTx struct{}
+
+func (hookTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, commit := application.WithAfterCommitHooks(ctx)
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
+
+type funcSink func(msg gorm.OutboxMessage) error
+
+func (f funcSink) Name() string { return "func" }
+
+func (f funcSink) Publish(ctx context.Context, msg gorm.OutboxMessage) error { return f(msg) }
+
+type funcSinkCtx func(ctx context.Context, msg gorm.OutboxMessage) error
+
+func (f funcSinkCtx) Name() string { return "func" }
+
+func (f funcSinkCtx) Publish(ctx context.Context, msg gorm.OutboxMessage) error { return f(ctx, msg) }
+
+func TestOutboxRelay_RelayBatch(t *testing.T) {
+	store := newFakeOutboxStore(
+		gorm.OutboxMessage{ID: 1, EventName: "user.registered"},
+		gorm.OutboxMessage{ID: 2, EventName: "user.registered"},
+		gorm.OutboxMessage{ID: 3, EventName: "user.registered", Attempts: 4},
+	)
+	var publishedInTx bool
+	sink := funcSinkCtx(func(ctx context.Context, msg gorm.OutboxMessage) error {
+		publishedInTx = publishedInTx || application.InTransaction(ctx)
+		if msg.ID != 1 {
+			return errors.New("unreachable")
+		}
+		return nil
+	})
+	relay := NewOutboxRelay(store, hookTx{}, discard, 10, time.Second, 5, NewLogSink(discard), sink)
+
+	claimed, published, err := relay.relayBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, claimed)
+	assert.WithinDuration(t, time.Now().Add(claimLease), store.claimed, time.Second)
+	// sink 호출은 트랜잭션 밖에서 한다
+	assert.False(t, publishedInTx)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []int64{1}, store.published)
+	assert.ErrorContains(t, store.failed[2], "func: unreachable")
+	assert.WithinDuration(t, time.Now().Add(queue.Backoff(1)), store.retryAt[2], time.Second)
+	// 다섯 번째 실패는 재시도하지 않는다
+	assert.Equal(t, []int64{3}, store.dead)
+	assert.NotContains(t, store.retryAt, int64(3))
+}
+
+func TestOutboxRelay_RunWaitsWhenNothingPublished(t *testing.T) {
+	store := newFakeOutboxStore(gorm.OutboxMessage{ID: 1}, gorm.OutboxMessage{ID: 2})
+	sink := funcSink(func(msg gorm.OutboxMessage) error { return errors.New("down") })
+	relay := NewOutboxRelay(store, hookTx{}, discard, 2, time.Hour, 5, sink)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	relay.Run(ctx)
+
+	// 배치가 꽉 찼어도 전부 실패했으면 바로 다시 읽지 않는다
+	assert.Equal(t, int32(1), store.claims.Load())
+}
+
+func TestOutboxRelay_ShutdownLeavesUnpublishedClaimed(t *testing.T) {
+	store := newFakeOutboxStore(gorm.OutboxMessage{ID: 1}, gorm.OutboxMessage{ID: 2})
+	ctx, cancel := context.WithCancel(context.Background())
+	sink := funcSinkCtx(func(ctx context.Context, msg gorm.OutboxMessage) error {
+		if msg.ID == 1 {
+			cancel()
+			return nil
+		}
+		return ctx.Err()
+	})
+	relay := NewOutboxRelay(store, hookTx{}, discard, 10, time.Second, 5, sink)
+
+	_, published, err := relay.relayBatch(ctx)
+
+	// 전달한 결과는 기록하고, 종료 때문에 실패한 메시지는 실패로 세지 않는다
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []int64{1}, store.published)
+	assert.Empty(t, store.failed)
+}
+
+func TestWebhookSink_SignsBody(t *testing.T) {
+	var body []byte
+	var signature, eventID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get("X-Signature")
+		eventID = r.Header.Get("X-Event-ID")
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, "secret", server.Client())
+	err := sink.Publish(context.Background(), gorm.OutboxMessage{ID: 7, EventName: "user.registered", Payload: []byte(`{"user_id":1}`)})
+
+	require.NoError(t, err)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), signature)
+	assert.Equal(t, "7", eventID)
+	assert.Contains(t, string(body), `"event":"user.registered"`)
+}
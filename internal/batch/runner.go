@@ -0,0 +1,191 @@
+package batch
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
+)
+
+// 분 시 일 월 요일 5개 필드와 @daily 같은 표현을 허용한다
+var scheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ErrLocked 는 다른 인스턴스가 같은 잡을 실행 중일 때 반환된다
+var ErrLocked = errors.New("job is running on another instance")
+
+type Job struct {
+	Name string
+	// Schedule 은 cron 표현식. 비어 있으면 RunOnce 로만 실행된다
+	Schedule string
+	// Timeout 이 0 이면 Runner 의 기본값을 쓴다
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+const (
+	statusRunning   = "running"
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
+)
+
+type jobRun struct {
+	ID         uint       `gorm:"column:id;primaryKey"`
+	JobName    string     `gorm:"column:job_name"`
+	Status     string     `gorm:"column:status"`
+	StartedAt  time.Time  `gorm:"column:started_at"`
+	FinishedAt *time.Time `gorm:"column:finished_at"`
+	Error      *string    `gorm:"column:error"`
+}
+
+func (jobRun) TableName() string {
+	return "batch_job_run"
+}
+
+type Runner struct {
+	db             *gorm.DB
+	log            *slog.Logger
+	defaultTimeout time.Duration
+	jobs           map[string]Job
+	cron           *cron.Cron
+	// 스케줄로 실행되는 잡의 상위 ctx. Run 이 받은 ctx 로 바뀌어서 종료할 때 실행 중인 잡도 취소된다
+	ctx context.Context
+}
+
+func NewRunner(db *gorm.DB, log *slog.Logger, defaultTimeout time.Duration, jobs ...Job) (*Runner, error) {
+	r := &Runner{
+		db:             db,
+		log:            log,
+		defaultTimeout: defaultTimeout,
+		jobs:           make(map[string]Job, len(jobs)),
+		ctx:            context.Background(),
+		// 같은 프로세스 안에서 이전 실행이 끝나지 않았으면 이번 실행은 건너뛴다
+		cron: cron.New(cron.WithParser(scheduleParser), cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger))),
+	}
+
+	for _, job := range jobs {
+		if job.Name == "" || job.Run == nil {
+			return nil, errors.New("job needs a name and a run function")
+		}
+		if _, ok := r.jobs[job.Name]; ok {
+			return nil, fmt.Errorf("duplicate job name %q", job.Name)
+		}
+		r.jobs[job.Name] = job
+
+		if job.Schedule == "" {
+			continue
+		}
+		job := job
+		_, err := r.cron.AddFunc(job.Schedule, func() {
+			if err := r.execute(r.ctx, job); err != nil && !errors.Is(err, ErrLocked) {
+				r.log.Error("Batch job failed", "job", job.Name, "error", err)
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q for job %s: %w", job.Schedule, job.Name, err)
+		}
+	}
+	return r, nil
+}
+
+// Run 은 ctx 가 끝날 때까지 스케줄대로 잡을 실행하고, 끝나면 실행 중인 잡을 기다린다
+func (r *Runner) Run(ctx context.Context) {
+	r.ctx = ctx
+	r.cron.Start()
+	r.log.Info("Batch scheduler started", "jobs", len(r.jobs))
+	<-ctx.Done()
+	r.log.Info("Stopping batch scheduler, waiting for running jobs...")
+	<-r.cron.Stop().Done()
+}
+
+// RunOnce 는 스케줄과 상관없이 잡을 한 번 실행한다
+func (r *Runner) RunOnce(ctx context.Context, name string) error {
+	job, ok := r.jobs[name]
+	if !ok {
+		return fmt.Errorf("unknown job %q", name)
+	}
+	return r.execute(ctx, job)
+}
+
+func (r *Runner) execute(ctx context.Context, job Job) (err error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	// advisory lock 은 세션 단위라서 잡이 끝날 때까지 커넥션 하나를 붙잡는다
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	locked, err := tryLock(ctx, conn, job.Name)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		r.log.Debug("Batch job skipped, locked by another instance", "job", job.Name)
+		return ErrLocked
+	}
+	defer func() {
+		_, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock(hashtext($1))`, lockName(job.Name))
+		err = errors.Join(err, unlockErr)
+	}()
+
+	run := &jobRun{JobName: job.Name, Status: statusRunning, StartedAt: time.Now()}
+	if err := r.db.WithContext(ctx).Create(run).Error; err != nil {
+		return fmt.Errorf("failed to record job run: %w", err)
+	}
+
+	timeout := job.Timeout
+	if timeout <= 0 {
+		timeout = r.defaultTimeout
+	}
+	jobCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log := r.log.With("job", job.Name, "run_id", run.ID)
+	log.Info("Batch job started")
+	runErr := safeRun(jobCtx, job.Run)
+
+	finishedAt := time.Now()
+	updates := map[string]interface{}{"status": statusSucceeded, "finished_at": finishedAt}
+	if runErr != nil {
+		updates["status"] = statusFailed
+		updates["error"] = runErr.Error()
+	}
+	// 잡이 타임아웃으로 끝났어도 결과는 기록해야 하므로 원래 ctx 의 취소와 분리한다
+	if err := r.db.WithContext(context.WithoutCancel(ctx)).Model(run).Updates(updates).Error; err != nil {
+		log.Error("Failed to record job result", "error", err)
+	}
+
+	log.Info("Batch job finished", "status", updates["status"], "elapsed", finishedAt.Sub(run.StartedAt))
+	return runErr
+}
+
+func tryLock(ctx context.Context, conn *sql.Conn, name string) (bool, error) {
+	var locked bool
+	err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, lockName(name)).Scan(&locked)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire job lock: %w", err)
+	}
+	return locked, nil
+}
+
+func lockName(job string) string {
+	return "batch:" + job
+}
+
+// 잡 하나의 panic 이 스케줄러 전체를 멈추지 않도록 에러로 바꾼다
+func safeRun(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+	return run(ctx)
+}
@@ -0,0 +1,137 @@
+package batch
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"module.resume/internal/infrastructure/persistence/migrations"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func noop(ctx context.Context) error { return nil }
+
+func TestNewRunner_Validates(t *testing.T) {
+	_, err := NewRunner(nil, discard, time.Minute, Job{Name: "a", Schedule: "not a schedule", Run: noop})
+	assert.Error(t, err)
+
+	_, err = NewRunner(nil, discard, time.Minute, Job{Name: "a", Run: noop}, Job{Name: "a", Run: noop})
+	assert.Error(t, err)
+
+	_, err = NewRunner(nil, discard, time.Minute, Job{Name: "a", Schedule: "@daily", Run: noop}, Job{Name: "b", Schedule: "*/5 * * * *", Run: noop})
+	assert.NoError(t, err)
+}
+
+func TestSafeRun_RecoversPanic(t *testing.T) {
+	err := safeRun(context.Background(), func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	assert.ErrorContains(t, err, "boom")
+}
+
+// TEST_DATABASE_URL 이 있을 때만 실제 Postgres 에 대해 실행한다
+func testDB(t *testing.T) *gorm.DB {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := migrations.New(sqlDB, discard)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return db
+}
+
+func TestRunner_Postgres(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	name := "test-job-" + time.Now().Format("150405.000000")
+	t.Cleanup(func() { db.Where("job_name = ?", name).Delete(&jobRun{}) })
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	runner, err := NewRunner(db, discard, 500*time.Millisecond, Job{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			close(started)
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	first := make(chan error, 1)
+	go func() { first <- runner.RunOnce(ctx, name) }()
+	<-started
+
+	// 첫 실행이 잠금을 잡고 있는 동안 두 번째 실행은 건너뛴다
+	assert.ErrorIs(t, runner.RunOnce(ctx, name), ErrLocked)
+
+	// 잡 기본 timeout 이 지나면 context 가 취소되고 실패로 기록된다
+	assert.ErrorIs(t, <-first, context.DeadlineExceeded)
+	close(release)
+
+	var runs []jobRun
+	require.NoError(t, db.Where("job_name = ?", name).Find(&runs).Error)
+	require.Len(t, runs, 1)
+	assert.Equal(t, statusFailed, runs[0].Status)
+	assert.NotNil(t, runs[0].FinishedAt)
+
+	assert.ErrorContains(t, runner.RunOnce(ctx, "missing"), "unknown job")
+}
+
+func TestRunner_RunCancelsScheduledJobs(t *testing.T) {
+	db := testDB(t)
+	name := "test-scheduled-" + time.Now().Format("150405.000000")
+	t.Cleanup(func() { db.Where("job_name = ?", name).Delete(&jobRun{}) })
+
+	started := make(chan struct{})
+	jobErr := make(chan error, 1)
+	runner, err := NewRunner(db, discard, time.Hour, Job{
+		Name:     name,
+		Schedule: "@every 1s",
+		Run: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			jobErr <- ctx.Err()
+			return ctx.Err()
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		runner.Run(ctx)
+		close(stopped)
+	}()
+	<-started
+
+	// 종료 신호가 오면 timeout 을 기다리지 않고 실행 중인 잡이 취소된다
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runner did not stop")
+	}
+	assert.ErrorIs(t, <-jobErr, context.Canceled)
+}
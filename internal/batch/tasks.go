@@ -0,0 +1,19 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+
+	"module.resume/internal/application"
+)
+
+// BuildExportHandler 는 데이터 내보내기 ZIP 을 만드는 작업을 처리한다
+func BuildExportHandler(exports application.ExportService) TaskHandler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		var p application.BuildExportPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+		return exports.Build(ctx, p)
+	}
+}
@@ -0,0 +1,130 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"module.resume/internal/infrastructure/queue"
+)
+
+// recordTimeout 은 작업 결과를 큐에 남기는 데 주는 시간. 작업이 timeout 을 다 써도 결과는 남아야 한다
+const recordTimeout = 10 * time.Second
+
+// TaskHandler 는 큐에서 꺼낸 작업 하나를 처리한다. 에러를 돌려주면 backoff 뒤에 재시도된다
+type TaskHandler func(ctx context.Context, payload json.RawMessage) error
+
+type Queue interface {
+	Dequeue(ctx context.Context, kinds []string, lease time.Duration) (*queue.Job, error)
+	Complete(ctx context.Context, job *queue.Job) error
+	Fail(ctx context.Context, job *queue.Job, cause error) error
+}
+
+type WorkerPool struct {
+	queue        Queue
+	log          *slog.Logger
+	workers      int
+	pollInterval time.Duration
+	timeout      time.Duration
+	handlers     map[string]TaskHandler
+	kinds        []string
+}
+
+func NewWorkerPool(q Queue, log *slog.Logger, workers int, pollInterval, timeout time.Duration) *WorkerPool {
+	return &WorkerPool{
+		queue:        q,
+		log:          log,
+		workers:      workers,
+		pollInterval: pollInterval,
+		timeout:      timeout,
+		handlers:     map[string]TaskHandler{},
+	}
+}
+
+// Handle 은 kind 작업을 처리할 핸들러를 등록한다. Run 전에 호출해야 한다
+func (p *WorkerPool) Handle(kind string, handler TaskHandler) {
+	if _, ok := p.handlers[kind]; !ok {
+		p.kinds = append(p.kinds, kind)
+	}
+	p.handlers[kind] = handler
+}
+
+// Run 은 ctx 가 끝날 때까지 작업을 처리하고, 끝나면 처리 중인 작업을 마친 뒤 돌아온다
+func (p *WorkerPool) Run(ctx context.Context) {
+	if len(p.kinds) == 0 {
+		p.log.Info("No task handlers registered, worker pool is idle")
+		<-ctx.Done()
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+	p.log.Info("Worker pool started", "workers", p.workers, "kinds", p.kinds)
+	wg.Wait()
+}
+
+func (p *WorkerPool) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := p.processNext(ctx)
+		if err != nil {
+			p.log.Error("Failed to process task", "error", err)
+		}
+		if processed {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// processNext 는 작업 하나를 처리하고, 처리한 작업이 있었는지 돌려준다
+func (p *WorkerPool) processNext(ctx context.Context) (bool, error) {
+	job, err := p.queue.Dequeue(ctx, p.kinds, p.lease())
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	// 종료 신호를 받아도 이미 꺼낸 작업은 timeout 안에서 끝까지 처리한다
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.timeout)
+	defer cancel()
+
+	log := p.log.With("task_id", job.ID, "kind", job.Kind, "attempt", job.Attempts)
+	runErr := safeRun(jobCtx, func(ctx context.Context) error {
+		return p.handlers[job.Kind](ctx, job.Payload)
+	})
+
+	recordCtx, cancelRecord := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancelRecord()
+	if runErr == nil {
+		if err := p.queue.Complete(recordCtx, job); err != nil {
+			return true, fmt.Errorf("failed to record task completion: %w", err)
+		}
+		return true, nil
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		log.Error("Task moved to dead letter", "error", runErr)
+	} else {
+		log.Warn("Task failed, will retry", "error", runErr, "retry_in", queue.Backoff(job.Attempts))
+	}
+	if err := p.queue.Fail(recordCtx, job, runErr); err != nil {
+		return true, fmt.Errorf("failed to record task failure: %w", err)
+	}
+	return true, nil
+}
+
+// lease 가 지나도록 running 인 작업은 다른 워커가 다시 가져가므로 timeout 보다 넉넉해야 한다
+func (p *WorkerPool) lease() time.Duration {
+	return p.timeout + time.Minute
+}
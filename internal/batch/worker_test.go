@@ -0,0 +1,120 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/infrastructure/queue"
+)
+
+type fakeQueue struct {
+	mu        sync.Mutex
+	pending   []*queue.Job
+	completed []int64
+	failed    map[int64]error
+}
+
+func (f *fakeQueue) Dequeue(ctx context.Context, kinds []string, lease time.Duration) (*queue.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.pending) == 0 {
+		return nil, nil
+	}
+	job := f.pending[0]
+	f.pending = f.pending[1:]
+	job.Attempts++
+	return job, nil
+}
+
+// 실제 큐처럼 끝난 ctx 로는 결과를 남기지 못한다
+func (f *fakeQueue) Complete(ctx context.Context, job *queue.Job) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.completed = append(f.completed, job.ID)
+	return nil
+}
+
+func (f *fakeQueue) Fail(ctx context.Context, job *queue.Job, cause error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed[job.ID] = cause
+	return nil
+}
+
+func TestWorkerPool_ProcessNext(t *testing.T) {
+	q := &fakeQueue{failed: map[int64]error{}}
+	pool := NewWorkerPool(q, discard, 1, time.Millisecond, time.Second)
+	var got []string
+	pool.Handle("email", func(ctx context.Context, payload json.RawMessage) error {
+		var body struct{ To string }
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return err
+		}
+		if body.To == "" {
+			return errors.New("missing recipient")
+		}
+		got = append(got, body.To)
+		return nil
+	})
+	pool.Handle("panics", func(ctx context.Context, payload json.RawMessage) error {
+		panic("boom")
+	})
+
+	q.pending = []*queue.Job{
+		{ID: 1, Kind: "email", Payload: json.RawMessage(`{"To":"a@example.com"}`), MaxAttempts: 3},
+		{ID: 2, Kind: "email", Payload: json.RawMessage(`{}`), MaxAttempts: 3},
+		{ID: 3, Kind: "panics", Payload: json.RawMessage(`{}`), MaxAttempts: 1},
+	}
+	ctx := context.Background()
+	for range 3 {
+		processed, err := pool.processNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	}
+	processed, err := pool.processNext(ctx)
+	assert.NoError(t, err)
+	assert.False(t, processed)
+
+	assert.Equal(t, []string{"a@example.com"}, got)
+	assert.Equal(t, []int64{1}, q.completed)
+	assert.EqualError(t, q.failed[2], "missing recipient")
+	assert.ErrorContains(t, q.failed[3], "boom")
+}
+
+func TestWorkerPool_RecordsOutcomeAfterTimeout(t *testing.T) {
+	q := &fakeQueue{failed: map[int64]error{}}
+	pool := NewWorkerPool(q, discard, 1, time.Millisecond, 10*time.Millisecond)
+	pool.Handle("slow", func(ctx context.Context, payload json.RawMessage) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	// ctx 를 보지 않고 timeout 을 넘겨 성공하는 핸들러
+	pool.Handle("stubborn", func(ctx context.Context, payload json.RawMessage) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	q.pending = []*queue.Job{
+		{ID: 1, Kind: "slow", MaxAttempts: 3},
+		{ID: 2, Kind: "stubborn", MaxAttempts: 3},
+	}
+
+	for range 2 {
+		processed, err := pool.processNext(context.Background())
+		require.NoError(t, err)
+		assert.True(t, processed)
+	}
+	assert.ErrorIs(t, q.failed[1], context.DeadlineExceeded)
+	assert.Equal(t, []int64{2}, q.completed)
+}
@@ -0,0 +1,627 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	HTTP     HTTPConfig     `yaml:"http"`
+	Database DatabaseConfig `yaml:"database"`
+	Redis    RedisConfig    `yaml:"redis"`
+	Cache    CacheConfig    `yaml:"cache"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Account  AccountConfig  `yaml:"account"`
+	Storage  StorageConfig  `yaml:"storage"`
+	Mail     MailConfig     `yaml:"mail"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	Log      LogConfig      `yaml:"log"`
+	Batch    BatchConfig    `yaml:"batch"`
+}
+
+type HTTPConfig struct {
+	Addr string `yaml:"addr"`
+	// PublicURL 은 메일 등 밖으로 나가는 링크의 앞부분 (예: https://api.example.com)
+	PublicURL         string        `yaml:"public_url"`
+	RequestTimeout    time.Duration `yaml:"request_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	// DrainDelay 동안은 readyz 만 실패시키고 요청은 계속 받아서 로드밸런서가 빠질 시간을 준다
+	DrainDelay time.Duration `yaml:"drain_delay"`
+	// ShutdownTimeout 은 종료 신호를 받은 뒤 처리 중인 요청을 기다리는 최대 시간
+	ShutdownTimeout    time.Duration `yaml:"shutdown_timeout"`
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout"`
+	// TrustedProxies 는 X-Forwarded-For 를 믿을 프록시의 IP 또는 CIDR. 비어 있으면 접속한 주소를 클라이언트 IP 로 쓴다
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+type DatabaseConfig struct {
+	URL             string        `yaml:"url"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	// MigrateOnStart 가 켜져 있으면 API 서버가 시작할 때 마이그레이션을 적용한다
+	MigrateOnStart bool `yaml:"migrate_on_start"`
+}
+
+type RedisConfig struct {
+	// URL 은 redis:// 또는 rediss:// 형식이며 인증 정보와 db 번호를 포함할 수 있다
+	URL string `yaml:"url"`
+	// Addrs 는 URL 의 주소 대신 사용할 sentinel 또는 cluster 시드 주소 목록
+	Addrs            []string `yaml:"addrs"`
+	SentinelMaster   string   `yaml:"sentinel_master"`
+	SentinelPassword string   `yaml:"sentinel_password"`
+	Cluster          bool     `yaml:"cluster"`
+}
+
+const (
+	CacheBackendRedis    = "redis"
+	CacheBackendMemory   = "memory"
+	CacheBackendPostgres = "postgres"
+)
+
+type CacheConfig struct {
+	Backend        string        `yaml:"backend"`
+	MemoryCapacity int           `yaml:"memory_capacity"`
+	UserTTL        time.Duration `yaml:"user_ttl"`
+}
+
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwt_secret"`
+	TokenTTL  time.Duration `yaml:"token_ttl"`
+	// URLSigningKey 는 로그인 없이 여는 링크의 서명 키. 비어 있으면 JWTSecret 에서 만든다
+	URLSigningKey string `yaml:"url_signing_key"`
+	// AdminUserIDs 에 있는 계정만 /admin API 를 쓸 수 있다
+	// email 은 확인 없이 가입할 수 있고 탈퇴한 계정의 주소를 다시 쓸 수 있어서 ID 로 정한다
+	AdminUserIDs []uint `yaml:"admin_user_ids"`
+}
+
+type AccountConfig struct {
+	// DeletionGracePeriod 동안은 탈퇴를 취소할 수 있고, 지나면 개인정보를 익명화한다
+	DeletionGracePeriod time.Duration `yaml:"deletion_grace_period"`
+	// ExportTTL 동안 데이터 내보내기 ZIP 을 내려받을 수 있다
+	ExportTTL time.Duration `yaml:"export_ttl"`
+	// EmailChangeTTL 안에 새 주소로 받은 링크로 확인해야 email 이 바뀐다
+	EmailChangeTTL time.Duration `yaml:"email_change_ttl"`
+	// EmailChangeUndoWindow 동안은 예전 주소로 받은 링크로 변경을 되돌릴 수 있다
+	EmailChangeUndoWindow time.Duration `yaml:"email_change_undo_window"`
+}
+
+const (
+	StorageBackendFS = "fs"
+	StorageBackendS3 = "s3"
+)
+
+// StorageConfig 는 프로필 사진 같은 파일을 두는 곳
+type StorageConfig struct {
+	Backend string `yaml:"backend"`
+	// FSRoot 는 fs 백엔드가 파일을 두는 디렉터리
+	FSRoot string `yaml:"fs_root"`
+	// S3 호환 저장소 (AWS S3, MinIO 등). path-style 주소를 쓴다
+	S3Endpoint  string `yaml:"s3_endpoint"`
+	S3Region    string `yaml:"s3_region"`
+	S3Bucket    string `yaml:"s3_bucket"`
+	S3AccessKey string `yaml:"s3_access_key"`
+	S3SecretKey string `yaml:"s3_secret_key"`
+	// AvatarMaxBytes 보다 큰 업로드는 거부한다
+	AvatarMaxBytes int `yaml:"avatar_max_bytes"`
+	// SignedURLTTL 동안 응답에 담긴 파일 링크를 열 수 있다
+	SignedURLTTL time.Duration `yaml:"signed_url_ttl"`
+}
+
+const (
+	MailBackendLog  = "log"
+	MailBackendSMTP = "smtp"
+)
+
+// MailConfig 는 사용자에게 보내는 알림 메일 설정. log 는 메일을 보내지 않으므로 개발 환경에서만 쓴다
+type MailConfig struct {
+	Backend string `yaml:"backend"`
+	From    string `yaml:"from"`
+	// SMTPAddr 는 host:port. 서버가 STARTTLS 를 지원하면 암호화해서 보낸다
+	SMTPAddr     string `yaml:"smtp_addr"`
+	SMTPUsername string `yaml:"smtp_username"`
+	SMTPPassword string `yaml:"smtp_password"`
+}
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+type TracingConfig struct {
+	Exporter    string `yaml:"exporter"`
+	ServiceName string `yaml:"service_name"`
+	// OTLPEndpoint 가 비어 있으면 OTEL_EXPORTER_OTLP_* 표준 환경변수를 따른다
+	OTLPEndpoint string  `yaml:"otlp_endpoint"`
+	OTLPInsecure bool    `yaml:"otlp_insecure"`
+	SampleRatio  float64 `yaml:"sample_ratio"`
+}
+
+type LogConfig struct {
+	// Level 은 debug, info, warn, error 중 하나
+	Level string `yaml:"level"`
+	// SQLLevel 은 GORM 로그 수준으로 silent, error, warn, info 중 하나
+	SQLLevel         string        `yaml:"sql_level"`
+	SlowSQLThreshold time.Duration `yaml:"slow_sql_threshold"`
+}
+
+type BatchConfig struct {
+	// JobTimeout 은 잡 한 번 실행의 최대 시간. 잡이 따로 정하면 그 값을 쓴다
+	JobTimeout time.Duration `yaml:"job_timeout"`
+	// DeletedUserRetention 이 지난 탈퇴 계정은 완전히 삭제한다
+	DeletedUserRetention          time.Duration `yaml:"deleted_user_retention"`
+	PurgeDeletedUsersSchedule     string        `yaml:"purge_deleted_users_schedule"`
+	AnonymizeDeletedUsersSchedule string        `yaml:"anonymize_deleted_users_schedule"`
+	PurgeExpiredExportsSchedule   string        `yaml:"purge_expired_exports_schedule"`
+	// 작업 큐 워커 설정
+	QueueWorkers      int           `yaml:"queue_workers"`
+	QueuePollInterval time.Duration `yaml:"queue_poll_interval"`
+	TaskTimeout       time.Duration `yaml:"task_timeout"`
+	// outbox relay 설정. OutboxWebhookURL 이 비어 있으면 이벤트를 로그로만 남긴다
+	OutboxPollInterval time.Duration `yaml:"outbox_poll_interval"`
+	OutboxBatchSize    int           `yaml:"outbox_batch_size"`
+	// OutboxMaxAttempts 번 실패한 이벤트는 dead 로 옮겨 더 이상 전달하지 않는다
+	OutboxMaxAttempts   int    `yaml:"outbox_max_attempts"`
+	OutboxWebhookURL    string `yaml:"outbox_webhook_url"`
+	OutboxWebhookSecret string `yaml:"outbox_webhook_secret"`
+}
+
+func Default() *Config {
+	return &Config{
+		HTTP: HTTPConfig{
+			Addr:               ":8080",
+			PublicURL:          "http://localhost:8080",
+			RequestTimeout:     10 * time.Second,
+			ReadHeaderTimeout:  5 * time.Second,
+			ReadTimeout:        15 * time.Second,
+			WriteTimeout:       30 * time.Second,
+			IdleTimeout:        60 * time.Second,
+			DrainDelay:         5 * time.Second,
+			ShutdownTimeout:    20 * time.Second,
+			HealthCheckTimeout: 2 * time.Second,
+		},
+		Database: DatabaseConfig{
+			MaxIdleConns:    10,
+			MaxOpenConns:    30,
+			ConnMaxLifetime: time.Hour,
+		},
+		Cache: CacheConfig{
+			Backend:        CacheBackendRedis,
+			MemoryCapacity: 10000,
+			UserTTL:        5 * time.Minute,
+		},
+		Auth: AuthConfig{
+			TokenTTL: time.Hour,
+		},
+		Account: AccountConfig{
+			DeletionGracePeriod:   14 * 24 * time.Hour,
+			ExportTTL:             72 * time.Hour,
+			EmailChangeTTL:        24 * time.Hour,
+			EmailChangeUndoWindow: 7 * 24 * time.Hour,
+		},
+		Storage: StorageConfig{
+			Backend:        StorageBackendFS,
+			FSRoot:         "./data/blobs",
+			S3Region:       "us-east-1",
+			AvatarMaxBytes: 5 << 20,
+			SignedURLTTL:   time.Hour,
+		},
+		Mail: MailConfig{
+			Backend: MailBackendLog,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			ServiceName: "module-resume-server",
+			SampleRatio: 1,
+		},
+		Log: LogConfig{
+			Level:            "info",
+			SQLLevel:         "warn",
+			SlowSQLThreshold: time.Second,
+		},
+		Batch: BatchConfig{
+			JobTimeout:                    10 * time.Minute,
+			DeletedUserRetention:          30 * 24 * time.Hour,
+			PurgeDeletedUsersSchedule:     "0 4 * * *",
+			AnonymizeDeletedUsersSchedule: "30 3 * * *",
+			PurgeExpiredExportsSchedule:   "0 * * * *",
+			QueueWorkers:                  4,
+			QueuePollInterval:             time.Second,
+			TaskTimeout:                   5 * time.Minute,
+			OutboxPollInterval:            time.Second,
+			OutboxBatchSize:               100,
+			OutboxMaxAttempts:             12,
+		},
+	}
+}
+
+// setting 은 하나의 설정값을 환경변수, 플래그와 연결한다
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	ptr   interface{}
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{"HTTP_ADDR", "http-addr", "HTTP listen address", &c.HTTP.Addr},
+		{"PUBLIC_URL", "public-url", "externally visible base URL used in links", &c.HTTP.PublicURL},
+		{"REQUEST_TIMEOUT", "request-timeout", "per request timeout", &c.HTTP.RequestTimeout},
+		{"HTTP_READ_HEADER_TIMEOUT", "http-read-header-timeout", "max time to read request headers", &c.HTTP.ReadHeaderTimeout},
+		{"HTTP_READ_TIMEOUT", "http-read-timeout", "max time to read the whole request", &c.HTTP.ReadTimeout},
+		{"HTTP_WRITE_TIMEOUT", "http-write-timeout", "max time to write the response", &c.HTTP.WriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", "http-idle-timeout", "keep-alive idle timeout", &c.HTTP.IdleTimeout},
+		{"DRAIN_DELAY", "drain-delay", "time to report not-ready before the server stops accepting requests", &c.HTTP.DrainDelay},
+		{"SHUTDOWN_TIMEOUT", "shutdown-timeout", "drain period for in-flight requests on shutdown", &c.HTTP.ShutdownTimeout},
+		{"HEALTH_CHECK_TIMEOUT", "health-check-timeout", "timeout of each readiness dependency check", &c.HTTP.HealthCheckTimeout},
+		{"TRUSTED_PROXIES", "trusted-proxies", "comma separated proxy IPs or CIDRs whose X-Forwarded-For is trusted", &c.HTTP.TrustedProxies},
+		{"DATABASE_URL", "database-url", "postgres connection string", &c.Database.URL},
+		{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "max idle connections in the pool", &c.Database.MaxIdleConns},
+		{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "max open connections in the pool", &c.Database.MaxOpenConns},
+		{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "max lifetime of a pooled connection", &c.Database.ConnMaxLifetime},
+		{"MIGRATE_ON_START", "migrate-on-start", "apply pending database migrations on startup", &c.Database.MigrateOnStart},
+		{"REDIS_URL", "redis-url", "redis:// or rediss:// url", &c.Redis.URL},
+		{"REDIS_ADDR", "redis-addr", "comma separated redis, sentinel or cluster addresses", &c.Redis.Addrs},
+		{"REDIS_SENTINEL_MASTER", "redis-sentinel-master", "sentinel master name", &c.Redis.SentinelMaster},
+		{"REDIS_SENTINEL_PASSWORD", "redis-sentinel-password", "sentinel password", &c.Redis.SentinelPassword},
+		{"REDIS_CLUSTER", "redis-cluster", "use redis cluster mode", &c.Redis.Cluster},
+		{"CACHE_BACKEND", "cache-backend", "redis, memory or postgres", &c.Cache.Backend},
+		{"MEMORY_CACHE_CAPACITY", "memory-cache-capacity", "max entries of the memory cache", &c.Cache.MemoryCapacity},
+		{"USER_CACHE_TTL", "user-cache-ttl", "ttl of cached user lookups", &c.Cache.UserTTL},
+		{"JWT_SECRET_KEY", "jwt-secret-key", "secret used to sign access tokens", &c.Auth.JWTSecret},
+		{"TOKEN_TTL", "token-ttl", "access token lifetime", &c.Auth.TokenTTL},
+		{"URL_SIGNING_KEY", "url-signing-key", "secret used to sign download links", &c.Auth.URLSigningKey},
+		{"ADMIN_USER_IDS", "admin-user-ids", "comma separated user IDs allowed to use the admin API", &c.Auth.AdminUserIDs},
+		{"ACCOUNT_DELETION_GRACE_PERIOD", "account-deletion-grace-period", "how long a deleted account can be restored before its personal data is erased", &c.Account.DeletionGracePeriod},
+		{"ACCOUNT_EXPORT_TTL", "account-export-ttl", "how long a data export can be downloaded", &c.Account.ExportTTL},
+		{"ACCOUNT_EMAIL_CHANGE_TTL", "account-email-change-ttl", "how long an email change can be confirmed from the new address", &c.Account.EmailChangeTTL},
+		{"ACCOUNT_EMAIL_CHANGE_UNDO_WINDOW", "account-email-change-undo-window", "how long an email change can be undone from the old address", &c.Account.EmailChangeUndoWindow},
+		{"STORAGE_BACKEND", "storage-backend", "fs or s3", &c.Storage.Backend},
+		{"STORAGE_FS_ROOT", "storage-fs-root", "directory used by the fs storage backend", &c.Storage.FSRoot},
+		{"STORAGE_S3_ENDPOINT", "storage-s3-endpoint", "S3 compatible endpoint URL", &c.Storage.S3Endpoint},
+		{"STORAGE_S3_REGION", "storage-s3-region", "S3 region used for request signing", &c.Storage.S3Region},
+		{"STORAGE_S3_BUCKET", "storage-s3-bucket", "S3 bucket name", &c.Storage.S3Bucket},
+		{"STORAGE_S3_ACCESS_KEY", "storage-s3-access-key", "S3 access key id", &c.Storage.S3AccessKey},
+		{"STORAGE_S3_SECRET_KEY", "storage-s3-secret-key", "S3 secret access key", &c.Storage.S3SecretKey},
+		{"AVATAR_MAX_BYTES", "avatar-max-bytes", "max size of an uploaded profile photo", &c.Storage.AvatarMaxBytes},
+		{"SIGNED_URL_TTL", "signed-url-ttl", "lifetime of signed file links in responses", &c.Storage.SignedURLTTL},
+		{"MAIL_BACKEND", "mail-backend", "log or smtp", &c.Mail.Backend},
+		{"MAIL_FROM", "mail-from", "sender address of notification emails", &c.Mail.From},
+		{"SMTP_ADDR", "smtp-addr", "SMTP server host:port", &c.Mail.SMTPAddr},
+		{"SMTP_USERNAME", "smtp-username", "SMTP username, empty for no authentication", &c.Mail.SMTPUsername},
+		{"SMTP_PASSWORD", "smtp-password", "SMTP password", &c.Mail.SMTPPassword},
+		{"TRACING_EXPORTER", "tracing-exporter", "none, stdout or otlp", &c.Tracing.Exporter},
+		{"TRACING_SERVICE_NAME", "tracing-service-name", "service.name resource attribute", &c.Tracing.ServiceName},
+		{"TRACING_OTLP_ENDPOINT", "tracing-otlp-endpoint", "OTLP/HTTP collector host:port", &c.Tracing.OTLPEndpoint},
+		{"TRACING_OTLP_INSECURE", "tracing-otlp-insecure", "send OTLP over plain HTTP", &c.Tracing.OTLPInsecure},
+		{"TRACING_SAMPLE_RATIO", "tracing-sample-ratio", "fraction of new traces to sample", &c.Tracing.SampleRatio},
+		{"LOG_LEVEL", "log-level", "debug, info, warn or error", &c.Log.Level},
+		{"LOG_SQL_LEVEL", "log-sql-level", "silent, error, warn or info", &c.Log.SQLLevel},
+		{"LOG_SLOW_SQL_THRESHOLD", "log-slow-sql-threshold", "queries slower than this are logged as warnings", &c.Log.SlowSQLThreshold},
+		{"BATCH_JOB_TIMEOUT", "batch-job-timeout", "default timeout of a batch job run", &c.Batch.JobTimeout},
+		{"DELETED_USER_RETENTION", "deleted-user-retention", "how long soft-deleted users are kept before purge", &c.Batch.DeletedUserRetention},
+		{"PURGE_DELETED_USERS_SCHEDULE", "purge-deleted-users-schedule", "cron schedule of the deleted user purge", &c.Batch.PurgeDeletedUsersSchedule},
+		{"ANONYMIZE_DELETED_USERS_SCHEDULE", "anonymize-deleted-users-schedule", "cron schedule of the deleted user anonymization", &c.Batch.AnonymizeDeletedUsersSchedule},
+		{"PURGE_EXPIRED_EXPORTS_SCHEDULE", "purge-expired-exports-schedule", "cron schedule of the expired data export cleanup", &c.Batch.PurgeExpiredExportsSchedule},
+		{"QUEUE_WORKERS", "queue-workers", "number of concurrent task queue workers", &c.Batch.QueueWorkers},
+		{"QUEUE_POLL_INTERVAL", "queue-poll-interval", "how often idle workers poll the task queue", &c.Batch.QueuePollInterval},
+		{"TASK_TIMEOUT", "task-timeout", "max time to process one queued task", &c.Batch.TaskTimeout},
+		{"OUTBOX_POLL_INTERVAL", "outbox-poll-interval", "how often the outbox relay looks for new events", &c.Batch.OutboxPollInterval},
+		{"OUTBOX_BATCH_SIZE", "outbox-batch-size", "max events relayed per transaction", &c.Batch.OutboxBatchSize},
+		{"OUTBOX_MAX_ATTEMPTS", "outbox-max-attempts", "failed deliveries before an event is dead-lettered", &c.Batch.OutboxMaxAttempts},
+		{"OUTBOX_WEBHOOK_URL", "outbox-webhook-url", "URL that receives domain events", &c.Batch.OutboxWebhookURL},
+		{"OUTBOX_WEBHOOK_SECRET", "outbox-webhook-secret", "HMAC key used to sign webhook bodies", &c.Batch.OutboxWebhookSecret},
+	}
+}
+
+// Load 는 기본값 < YAML 파일 < 환경변수 < 플래그 순서로 설정을 덮어쓴다
+func Load(args []string) (*Config, error) {
+	cfg, _, err := LoadWithArgs(args)
+	return cfg, err
+}
+
+// LoadWithArgs 는 Load 와 같고, 플래그 뒤에 남은 인자(서브커맨드 등)도 돌려준다
+func LoadWithArgs(args []string) (*Config, []string, error) {
+	return LoadWithFlags(args, nil)
+}
+
+// LoadWithFlags 는 LoadWithArgs 와 같고, 실행 파일에만 있는 플래그를 define 으로 같은 FlagSet 에 등록한다
+func LoadWithFlags(args []string, define func(fs *flag.FlagSet)) (*Config, []string, error) {
+	cfg := Default()
+	settings := cfg.settings()
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	flagValues := make(map[string]*string, len(settings))
+	for _, s := range settings {
+		flagValues[s.flag] = fs.String(s.flag, "", s.usage+" ($"+s.env+")")
+	}
+	if define != nil {
+		define(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *configFile != "" {
+		if err := loadFile(cfg, *configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var errs []error
+	for _, s := range settings {
+		if v, ok := os.LookupEnv(s.env); ok && v != "" {
+			if err := assign(s.ptr, v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.env, err))
+			}
+		}
+	}
+	fs.Visit(func(f *flag.Flag) {
+		v, ok := flagValues[f.Name]
+		if !ok {
+			return
+		}
+		for _, s := range settings {
+			if s.flag == f.Name {
+				if err := assign(s.ptr, *v); err != nil {
+					errs = append(errs, fmt.Errorf("-%s: %w", s.flag, err))
+				}
+			}
+		}
+	})
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func assign(ptr interface{}, v string) error {
+	switch p := ptr.(type) {
+	case *string:
+		*p = v
+	case *int:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", v)
+		}
+		*p = n
+	case *float64:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", v)
+		}
+		*p = f
+	case *bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", v)
+		}
+		*p = b
+	case *time.Duration:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", v)
+		}
+		*p = d
+	case *[]string:
+		*p = nil
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*p = append(*p, item)
+			}
+		}
+	case *[]uint:
+		*p = nil
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			n, err := strconv.ParseUint(item, 10, 0)
+			if err != nil || n == 0 {
+				return fmt.Errorf("invalid ID %q", item)
+			}
+			*p = append(*p, uint(n))
+		}
+	default:
+		return fmt.Errorf("unsupported setting type %T", ptr)
+	}
+	return nil
+}
+
+// Validate 는 첫 번째 문제에서 멈추지 않고 모든 문제를 한 번에 모아서 반환한다
+func (c *Config) Validate() error {
+	var problems []string
+	missing := func(env string) {
+		problems = append(problems, env+" is required")
+	}
+
+	if c.HTTP.Addr == "" {
+		missing("HTTP_ADDR")
+	}
+	if c.HTTP.PublicURL == "" {
+		missing("PUBLIC_URL")
+	}
+	if c.HTTP.RequestTimeout <= 0 {
+		problems = append(problems, "REQUEST_TIMEOUT must be positive")
+	}
+	if c.HTTP.WriteTimeout > 0 && c.HTTP.WriteTimeout < c.HTTP.RequestTimeout {
+		problems = append(problems, "HTTP_WRITE_TIMEOUT must not be shorter than REQUEST_TIMEOUT")
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		problems = append(problems, "SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.HTTP.DrainDelay < 0 {
+		problems = append(problems, "DRAIN_DELAY must not be negative")
+	}
+	if c.HTTP.HealthCheckTimeout <= 0 {
+		problems = append(problems, "HEALTH_CHECK_TIMEOUT must be positive")
+	}
+	for _, proxy := range c.HTTP.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			problems = append(problems, fmt.Sprintf("TRUSTED_PROXIES must contain IPs or CIDRs (got %q)", proxy))
+		}
+	}
+	if c.Database.URL == "" {
+		missing("DATABASE_URL")
+	}
+	if c.Database.MaxOpenConns <= 0 {
+		problems = append(problems, "DB_MAX_OPEN_CONNS must be positive")
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		problems = append(problems, "DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+
+	switch c.Cache.Backend {
+	case CacheBackendRedis:
+		if c.Redis.URL == "" && len(c.Redis.Addrs) == 0 {
+			missing("REDIS_URL or REDIS_ADDR")
+		}
+	case CacheBackendMemory, CacheBackendPostgres:
+	default:
+		problems = append(problems, fmt.Sprintf("CACHE_BACKEND must be one of redis, memory, postgres (got %q)", c.Cache.Backend))
+	}
+	if c.Cache.UserTTL <= 0 {
+		problems = append(problems, "USER_CACHE_TTL must be positive")
+	}
+
+	if c.Auth.JWTSecret == "" {
+		missing("JWT_SECRET_KEY")
+	}
+	if c.Auth.TokenTTL <= 0 {
+		problems = append(problems, "TOKEN_TTL must be positive")
+	}
+
+	if c.Account.DeletionGracePeriod <= 0 {
+		problems = append(problems, "ACCOUNT_DELETION_GRACE_PERIOD must be positive")
+	}
+	if c.Account.ExportTTL <= 0 {
+		problems = append(problems, "ACCOUNT_EXPORT_TTL must be positive")
+	}
+	if c.Account.EmailChangeTTL <= 0 {
+		problems = append(problems, "ACCOUNT_EMAIL_CHANGE_TTL must be positive")
+	}
+	// 확인되기 전에 되돌리기 링크가 먼저 만료되면 예전 주소의 주인이 막을 방법이 없다
+	if c.Account.EmailChangeUndoWindow < c.Account.EmailChangeTTL {
+		problems = append(problems, "ACCOUNT_EMAIL_CHANGE_UNDO_WINDOW must not be shorter than ACCOUNT_EMAIL_CHANGE_TTL")
+	}
+
+	switch c.Storage.Backend {
+	case StorageBackendFS:
+		if c.Storage.FSRoot == "" {
+			missing("STORAGE_FS_ROOT")
+		}
+	case StorageBackendS3:
+		if c.Storage.S3Endpoint == "" {
+			missing("STORAGE_S3_ENDPOINT")
+		}
+		if c.Storage.S3Bucket == "" {
+			missing("STORAGE_S3_BUCKET")
+		}
+		if c.Storage.S3AccessKey == "" || c.Storage.S3SecretKey == "" {
+			missing("STORAGE_S3_ACCESS_KEY and STORAGE_S3_SECRET_KEY")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("STORAGE_BACKEND must be one of fs, s3 (got %q)", c.Storage.Backend))
+	}
+	if c.Storage.AvatarMaxBytes <= 0 {
+		problems = append(problems, "AVATAR_MAX_BYTES must be positive")
+	}
+	if c.Storage.SignedURLTTL <= 0 {
+		problems = append(problems, "SIGNED_URL_TTL must be positive")
+	}
+
+	switch c.Mail.Backend {
+	case MailBackendLog:
+	case MailBackendSMTP:
+		if c.Mail.SMTPAddr == "" {
+			missing("SMTP_ADDR")
+		} else if _, _, err := net.SplitHostPort(c.Mail.SMTPAddr); err != nil {
+			problems = append(problems, fmt.Sprintf("SMTP_ADDR must be host:port (got %q)", c.Mail.SMTPAddr))
+		}
+		if c.Mail.From == "" {
+			missing("MAIL_FROM")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("MAIL_BACKEND must be one of log, smtp (got %q)", c.Mail.Backend))
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterStdout, TracingExporterOTLP:
+	default:
+		problems = append(problems, fmt.Sprintf("TRACING_EXPORTER must be one of none, stdout, otlp (got %q)", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, "TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL must be one of debug, info, warn, error (got %q)", c.Log.Level))
+	}
+	switch c.Log.SQLLevel {
+	case "silent", "error", "warn", "info":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_SQL_LEVEL must be one of silent, error, warn, info (got %q)", c.Log.SQLLevel))
+	}
+
+	if c.Batch.JobTimeout <= 0 {
+		problems = append(problems, "BATCH_JOB_TIMEOUT must be positive")
+	}
+	// 익명화와 연관 데이터 삭제가 끝나기 전에 계정 행을 지우면 안 된다
+	if c.Batch.DeletedUserRetention < c.Account.DeletionGracePeriod {
+		problems = append(problems, "DELETED_USER_RETENTION must not be shorter than ACCOUNT_DELETION_GRACE_PERIOD")
+	}
+	if c.Batch.QueueWorkers <= 0 {
+		problems = append(problems, "QUEUE_WORKERS must be positive")
+	}
+	if c.Batch.QueuePollInterval <= 0 {
+		problems = append(problems, "QUEUE_POLL_INTERVAL must be positive")
+	}
+	if c.Batch.TaskTimeout <= 0 {
+		problems = append(problems, "TASK_TIMEOUT must be positive")
+	}
+	if c.Batch.OutboxPollInterval <= 0 {
+		problems = append(problems, "OUTBOX_POLL_INTERVAL must be positive")
+	}
+	if c.Batch.OutboxBatchSize <= 0 {
+		problems = append(problems, "OUTBOX_BATCH_SIZE must be positive")
+	}
+	if c.Batch.OutboxMaxAttempts <= 0 {
+		problems = append(problems, "OUTBOX_MAX_ATTEMPTS must be positive")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
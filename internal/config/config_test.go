@@ -0,0 +1,148 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setRequiredEnv(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://localhost/resume")
+	t.Setenv("REDIS_ADDR", "localhost:6379")
+	t.Setenv("JWT_SECRET_KEY", "secret")
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, err := Load(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.HTTP.Addr)
+	assert.Equal(t, 10*time.Second, cfg.HTTP.RequestTimeout)
+	assert.Equal(t, 30, cfg.Database.MaxOpenConns)
+	assert.Equal(t, time.Hour, cfg.Auth.TokenTTL)
+	assert.Equal(t, []string{"localhost:6379"}, cfg.Redis.Addrs)
+	assert.Equal(t, CacheBackendRedis, cfg.Cache.Backend)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	setRequiredEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`
+http:
+  addr: ":9000"
+  request_timeout: 5s
+database:
+  max_open_conns: 50
+auth:
+  token_ttl: 30m
+`), 0o600)
+	assert.NoError(t, err)
+	t.Setenv("DB_MAX_OPEN_CONNS", "40")
+
+	cfg, err := Load([]string{"-config", path, "-http-addr", ":7000"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":7000", cfg.HTTP.Addr)
+	assert.Equal(t, 5*time.Second, cfg.HTTP.RequestTimeout)
+	assert.Equal(t, 40, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 30*time.Minute, cfg.Auth.TokenTTL)
+	assert.Equal(t, "postgres://localhost/resume", cfg.Database.URL)
+}
+
+func TestLoad_ReportsEveryMissingSetting(t *testing.T) {
+	t.Setenv("DATABASE_URL", "")
+	t.Setenv("REDIS_ADDR", "")
+	t.Setenv("REDIS_URL", "")
+	t.Setenv("JWT_SECRET_KEY", "")
+
+	_, err := Load(nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DATABASE_URL is required")
+	assert.Contains(t, err.Error(), "REDIS_URL or REDIS_ADDR is required")
+	assert.Contains(t, err.Error(), "JWT_SECRET_KEY is required")
+}
+
+func TestLoad_MemoryBackendDoesNotNeedRedis(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://localhost/resume")
+	t.Setenv("JWT_SECRET_KEY", "secret")
+	t.Setenv("REDIS_ADDR", "")
+
+	cfg, err := Load([]string{"-cache-backend", "memory"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, CacheBackendMemory, cfg.Cache.Backend)
+}
+
+func TestLoadWithArgs_ReturnsSubcommand(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, args, err := LoadWithArgs([]string{"-log-level", "debug", "run", "purge-deleted-users"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, []string{"run", "purge-deleted-users"}, args)
+}
+
+func TestLoadWithFlags_CommandFlags(t *testing.T) {
+	setRequiredEnv(t)
+
+	var dir string
+	cfg, args, err := LoadWithFlags([]string{"-dir", "db/sql", "-database-url", "postgres://db/other", "up"}, func(fs *flag.FlagSet) {
+		fs.StringVar(&dir, "dir", "default", "")
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "db/sql", dir)
+	assert.Equal(t, "postgres://db/other", cfg.Database.URL)
+	assert.Equal(t, []string{"up"}, args)
+}
+
+func TestLoad_InvalidValues(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("REQUEST_TIMEOUT", "ten seconds")
+	t.Setenv("DB_MAX_IDLE_CONNS", "many")
+	t.Setenv("ADMIN_USER_IDS", "1,admin@example.com")
+
+	_, err := Load(nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "REQUEST_TIMEOUT")
+	assert.Contains(t, err.Error(), "DB_MAX_IDLE_CONNS")
+	assert.Contains(t, err.Error(), "ADMIN_USER_IDS")
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8,192.0.2.1,load-balancer")
+
+	_, err := Load(nil)
+
+	assert.ErrorContains(t, err, `TRUSTED_PROXIES must contain IPs or CIDRs (got "load-balancer")`)
+}
+
+func TestLoad_AdminUserIDs(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("ADMIN_USER_IDS", "3, 14,")
+
+	cfg, err := Load(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{3, 14}, cfg.Auth.AdminUserIDs)
+}
+
+func TestLoad_SMTPMailRequiresServerAndSender(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("MAIL_BACKEND", "smtp")
+
+	_, err := Load(nil)
+
+	assert.ErrorContains(t, err, "SMTP_ADDR is required")
+	assert.ErrorContains(t, err, "MAIL_FROM is required")
+}
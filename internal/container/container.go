@@ -1,53 +1,167 @@
 package container
 
 import (
+	"context"
 	"errors"
-	"os"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"module.resume/internal/api"
 	"module.resume/internal/api/handler"
 	"module.resume/internal/api/middleware"
 	"module.resume/internal/application"
-	"module.resume/internal/infrastructure/cache"
-	"module.resume/internal/infrastructure/persistence/gorm"
+	"module.resume/internal/config"
+	"module.resume/internal/infrastructure/metrics"
+	"module.resume/internal/listing"
 )
 
 type Container struct {
+	*Core
+
 	Router *gin.Engine
+	Server *http.Server
+	Health *handler.HealthHandler
 }
 
-func NewContainer() (*Container, error) {
-	db, err := gorm.NewDB()
+func NewContainer(cfg *config.Config, log *slog.Logger) (*Container, error) {
+	core, err := NewCore(cfg, log)
 	if err != nil {
 		return nil, err
 	}
+	c := &Container{Core: core}
+
+	userRepo := core.UserRepo
+	m := core.Metrics
+
+	auditLog := application.NewAuditLog(core.Audits, core.Log)
+
+	userService := application.AuditUserService(
+		application.NewUserService(userRepo, core.TxManager, core.Outbox, cfg.Account.DeletionGracePeriod),
+		userRepo, auditLog,
+	)
+	avatarService := core.NewAvatarService()
+	userHandler := handler.NewUserHandler(userService, avatarService)
+
+	authService := metrics.InstrumentAuthService(
+		application.AuditAuthService(
+			application.NewAuthService(userRepo, core.Cache, cfg.Auth.JWTSecret, cfg.Auth.TokenTTL),
+			userRepo, auditLog,
+		),
+		m,
+	)
+	authHandler := handler.NewAuthHandler(authService)
 
-	userRepo := gorm.NewUserRepository(db)
-	userService := application.NewUserService(userRepo)
-	userHandler := handler.NewUserHandler(userService)
+	exportHandler := handler.NewExportHandler(core.NewExportService())
+
+	emailHandler := handler.NewEmailChangeHandler(application.AuditEmailChangeService(
+		application.NewEmailChangeService(
+			userRepo, core.EmailChanges, core.TxManager, core.Outbox, core.Mailer,
+			cfg.HTTP.PublicURL, cfg.Account.EmailChangeTTL, cfg.Account.EmailChangeUndoWindow,
+		),
+		auditLog,
+	))
+
+	avatarHandler := handler.NewAvatarHandler(avatarService, cfg.Storage.AvatarMaxBytes)
+
+	auditHandler := handler.NewAuditHandler(auditLog)
+	adminHandler := handler.NewAdminHandler(
+		application.NewAdminService(core.Users, auditLog),
+		listing.NewCodec(urlSigningKey(cfg.Auth)),
+	)
+
+	c.Health = handler.NewHealthHandler(c.healthChecks()...)
+
+	h := &handler.Handlers{
+		User:    userHandler,
+		Auth:    authHandler,
+		Export:  exportHandler,
+		Email:   emailHandler,
+		Avatar:  avatarHandler,
+		Audit:   auditHandler,
+		Admin:   adminHandler,
+		Health:  c.Health,
+		Metrics: m.Handler(),
+	}
+	mw := &middleware.Middlewares{
+		Auth:    middleware.AuthMiddleware(authService),
+		Admin:   middleware.AdminMiddleware(cfg.Auth.AdminUserIDs),
+		Timeout: middleware.TimeoutMiddleware(cfg.HTTP.RequestTimeout),
+		Metrics: middleware.MetricsMiddleware(m),
+		Tracing: middleware.TracingMiddleware(core.TracerProvider),
+	}
 
-	redis, err := cache.NewRedisClient()
+	c.Router, err = api.MakeRouter(h, mw, cfg.HTTP.TrustedProxies)
 	if err != nil {
+		_ = core.Close()
 		return nil, err
 	}
-	cache := cache.NewRedisCache(redis)
-	jwtSecret := os.Getenv("JWT_SECRET_KEY")
-	if jwtSecret == "" {
-		return nil, errors.New("JWT secret key not set")
+	c.Server = &http.Server{
+		Addr:              cfg.HTTP.Addr,
+		Handler:           c.Router,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
 	}
-	authService := application.NewAuthService(userRepo, cache, jwtSecret)
-	authHandler := handler.NewAuthHandler(authService)
-	authMiddleWare := middleware.AuthMiddleware(authService)
 
-	h := &handler.Handlers{
-		User: userHandler,
-		Auth: authHandler,
+	return c, nil
+}
+
+// Run 은 ctx 가 끝날 때까지 서버를 실행하고, 끝나면 ShutdownTimeout 동안 처리 중인 요청을 기다린다
+func (c *Container) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		c.Log.Info("Listening", "addr", c.Server.Addr)
+		if err := c.Server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	c.Log.Info("Shutting down server...")
+	if c.Health != nil {
+		c.Health.SetDraining()
 	}
+	time.Sleep(c.Config.HTTP.DrainDelay)
 
-	r := api.MakeRouter(h, authMiddleWare)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), c.Config.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := c.Server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to drain in-flight requests: %w", err)
+	}
+	return <-errCh
+}
 
-	return &Container{
-		Router: r,
-	}, nil
+func (c *Container) healthChecks() []handler.HealthCheck {
+	timeout := c.Config.HTTP.HealthCheckTimeout
+	checks := []handler.HealthCheck{{
+		Name:    "postgres",
+		Timeout: timeout,
+		Check: func(ctx context.Context) error {
+			sqlDB, err := c.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}}
+	if c.Redis != nil {
+		checks = append(checks, handler.HealthCheck{
+			Name:    "redis",
+			Timeout: timeout,
+			Check: func(ctx context.Context) error {
+				return c.Redis.Ping(ctx).Err()
+			},
+		})
+	}
+	return checks
 }
@@ -0,0 +1,231 @@
+package container
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/trace"
+	gormio "gorm.io/gorm"
+	"module.resume/internal/application"
+	"module.resume/internal/auth"
+	"module.resume/internal/config"
+	"module.resume/internal/infrastructure/blob"
+	"module.resume/internal/infrastructure/cache"
+	"module.resume/internal/infrastructure/mail"
+	"module.resume/internal/infrastructure/metrics"
+	"module.resume/internal/infrastructure/persistence/gorm"
+	"module.resume/internal/infrastructure/persistence/migrations"
+	"module.resume/internal/infrastructure/queue"
+	"module.resume/internal/infrastructure/tracing"
+)
+
+// Core 는 API 서버와 배치가 함께 쓰는 인프라와 저장소를 묶는다
+type Core struct {
+	Config         *config.Config
+	Log            *slog.Logger
+	DB             *gormio.DB
+	Redis          redis.UniversalClient
+	Cache          application.Cache
+	Metrics        *metrics.Metrics
+	TracerProvider trace.TracerProvider
+	Queue          *queue.PostgresQueue
+	TxManager      *gorm.TxManager
+	Outbox         *gorm.OutboxRepository
+	Exports        *gorm.ExportRepository
+	EmailChanges   *gorm.EmailChangeRepository
+	Audits         *gorm.AuditRepository
+	Mailer         application.Mailer
+	URLSigner      *auth.URLSigner
+	Blobs          application.BlobStore
+
+	// Users 는 캐시를 거치지 않는 저장소, UserRepo 는 캐시를 거치는 저장소
+	Users    *gorm.UserRepository
+	UserRepo *cache.CachedUserRepository
+
+	shutdownTracer func(context.Context) error
+}
+
+func NewCore(cfg *config.Config, log *slog.Logger) (*Core, error) {
+	c := &Core{Config: cfg, Log: log}
+
+	tp, shutdownTracer, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+	c.TracerProvider, c.shutdownTracer = tp, shutdownTracer
+
+	db, err := gorm.NewDB(cfg.Database, cfg.Log, log)
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	c.DB = db
+	if err := gorm.RegisterTracing(db, tp); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	if cfg.Database.MigrateOnStart {
+		if err := c.migrate(); err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+	}
+
+	c.Cache, err = c.newCache()
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	c.Metrics = metrics.New()
+	c.Metrics.RegisterDBStats(sqlDB, "postgres")
+
+	c.Queue = queue.NewPostgresQueue(db)
+	c.TxManager = gorm.NewTxManager(db)
+	c.Outbox = gorm.NewOutboxRepository(db)
+	c.Exports = gorm.NewExportRepository(db)
+	c.EmailChanges = gorm.NewEmailChangeRepository(db)
+	c.Audits = gorm.NewAuditRepository(db)
+	c.Mailer, err = c.newMailer()
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	c.URLSigner = auth.NewURLSigner(urlSigningKey(cfg.Auth))
+	c.Blobs, err = c.newBlobStore()
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	c.Users = gorm.NewUserRepository(db)
+	c.UserRepo = cache.NewCachedUserRepository(c.Users, c.Cache, cfg.Cache.UserTTL)
+	c.Metrics.RegisterCacheStats("user", func() (uint64, uint64) {
+		stats := c.UserRepo.Stats()
+		return stats.Hits, stats.Misses
+	})
+
+	return c, nil
+}
+
+// NewExportService 는 API 서버(요청, 다운로드)와 배치(ZIP 생성)가 같은 설정으로 쓰도록 여기서 만든다
+func (c *Core) NewExportService() application.ExportService {
+	return application.NewExportService(c.UserRepo, c.Exports, c.TxManager, c.Queue, c.Mailer,
+		c.URLSigner, c.Config.HTTP.PublicURL, c.Config.Account.ExportTTL,
+		c.Outbox, c.EmailChanges, c.Audits,
+	)
+}
+
+// NewAvatarService 는 API 서버(업로드, 링크)와 배치(탈퇴 사용자 사진 삭제)가 함께 쓴다
+func (c *Core) NewAvatarService() application.AvatarService {
+	return application.NewAvatarService(c.UserRepo, c.Blobs, c.TxManager, c.URLSigner,
+		c.Config.HTTP.PublicURL, c.Config.Storage.SignedURLTTL)
+}
+
+// Close 는 DB 커넥션 풀, Redis 클라이언트, 남은 span 전송 순서로 정리한다
+func (c *Core) Close() error {
+	var errs []error
+	if c.DB != nil {
+		if sqlDB, err := c.DB.DB(); err != nil {
+			errs = append(errs, err)
+		} else if err := sqlDB.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close database: %w", err))
+		}
+	}
+	if c.Redis != nil {
+		if err := c.Redis.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close redis: %w", err))
+		}
+	}
+	if c.shutdownTracer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := c.shutdownTracer(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush traces: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Core) migrate() error {
+	sqlDB, err := c.DB.DB()
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.New(sqlDB, c.Log)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return err
+	}
+	c.Log.Info("Database schema is up to date", "applied", applied)
+	return nil
+}
+
+// urlSigningKey 는 따로 정한 키가 없으면 JWT 비밀키에서 용도별 키를 만들어 같은 키를 두 곳에 쓰지 않는다
+func urlSigningKey(cfg config.AuthConfig) string {
+	if cfg.URLSigningKey != "" {
+		return cfg.URLSigningKey
+	}
+	mac := hmac.New(sha256.New, []byte(cfg.JWTSecret))
+	mac.Write([]byte("url-signing"))
+	return string(mac.Sum(nil))
+}
+
+func (c *Core) newBlobStore() (application.BlobStore, error) {
+	cfg := c.Config.Storage
+	switch cfg.Backend {
+	case config.StorageBackendFS:
+		return blob.NewFSStore(cfg.FSRoot)
+	case config.StorageBackendS3:
+		return blob.NewS3Store(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey,
+			&http.Client{Timeout: 30 * time.Second})
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %q", cfg.Backend)
+	}
+}
+
+func (c *Core) newMailer() (application.Mailer, error) {
+	cfg := c.Config.Mail
+	switch cfg.Backend {
+	case config.MailBackendSMTP:
+		return mail.NewSMTPMailer(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case config.MailBackendLog:
+		c.Log.Warn("Mail backend is log, notification emails are not delivered")
+		return mail.NewLogMailer(c.Log), nil
+	default:
+		return nil, fmt.Errorf("unknown mail backend: %q", cfg.Backend)
+	}
+}
+
+func (c *Core) newCache() (application.Cache, error) {
+	switch c.Config.Cache.Backend {
+	case config.CacheBackendRedis:
+		rdb, err := cache.NewRedisClient(c.Config.Redis, c.Log)
+		if err != nil {
+			return nil, err
+		}
+		rdb.AddHook(cache.NewTracingHook(c.TracerProvider))
+		c.Redis = rdb
+		return cache.NewRedisCache(rdb), nil
+	case config.CacheBackendMemory:
+		return cache.NewMemoryCache(c.Config.Cache.MemoryCapacity), nil
+	case config.CacheBackendPostgres:
+		return cache.NewPostgresCache(c.DB), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend: %q", c.Config.Cache.Backend)
+	}
+}
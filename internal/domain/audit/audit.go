@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// 보안과 관련된 계정 활동. 이름은 바꾸지 않는다 (저장된 기록과 조회 필터가 이 값을 쓴다)
+const (
+	ActionLoginSucceeded       = "auth.login_succeeded"
+	ActionLoginFailed          = "auth.login_failed"
+	ActionLogout               = "auth.logout"
+	ActionTokensRevoked        = "auth.tokens_revoked"
+	ActionPasswordChanged      = "user.password_changed"
+	ActionPasswordChangeFailed = "user.password_change_failed"
+	ActionEmailChangeRequested = "user.email_change_requested"
+	ActionEmailChanged         = "user.email_changed"
+	ActionEmailChangeUndone    = "user.email_change_undone"
+	ActionAccountDeleted       = "user.deleted"
+	ActionDeletionCancelled    = "user.deletion_cancelled"
+	ActionAdminAuditSearched   = "admin.audit_searched"
+	ActionAdminUsersSearched   = "admin.users_searched"
+)
+
+// Entry 는 감사 기록 한 건. 한 번 쓰면 바꾸지 않는다
+type Entry struct {
+	ID         int64
+	OccurredAt time.Time
+	Action     string
+	// ActorID 는 행동한 사용자, SubjectID 는 영향을 받은 계정. 모르면 0
+	ActorID   uint
+	SubjectID uint
+	IP        string
+	UserAgent string
+	RequestID string
+	Metadata  map[string]string
+}
+
+// Filter 의 빈 값은 조건에서 빠진다
+type Filter struct {
+	ActorID   uint
+	SubjectID uint
+	Action    string
+	IP        string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+type Repository interface {
+	Append(ctx context.Context, entry *Entry) error
+	// Search 는 최근 기록부터 돌려준다
+	Search(ctx context.Context, filter Filter) ([]Entry, error)
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "errors"
+
+// 에러 종류. HTTP 상태 코드로의 변환은 api 계층에서 한다
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrValidation   = errors.New("validation failed")
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error 는 에러 종류(kind)와 사용자에게 보여줄 설명을 함께 담는다
+// errors.Is(err, ErrNotFound) 처럼 종류로 비교할 수 있다
+type Error struct {
+	kind   error
+	Detail string
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	if e.Detail == "" {
+		return e.kind.Error()
+	}
+	return e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+func NotFound(detail string) error {
+	return &Error{kind: ErrNotFound, Detail: detail}
+}
+
+func Conflict(detail string) error {
+	return &Error{kind: ErrConflict, Detail: detail}
+}
+
+func Unauthorized(detail string) error {
+	return &Error{kind: ErrUnauthorized, Detail: detail}
+}
+
+func Forbidden(detail string) error {
+	return &Error{kind: ErrForbidden, Detail: detail}
+}
+
+func Validation(detail string, fields ...FieldError) error {
+	return &Error{kind: ErrValidation, Detail: detail, Fields: fields}
+}
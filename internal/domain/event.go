@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Event 는 aggregate 의 상태 변화를 다른 모듈(메일, 검색 색인, 웹훅 등)에 알리는 사실
+type Event interface {
+	// EventName 은 "user.registered" 처럼 aggregate.동작 형식
+	EventName() string
+	AggregateType() string
+	AggregateID() string
+	OccurredAt() time.Time
+}
+
+// Events 를 aggregate 에 포함하면 상태 변경과 함께 이벤트를 쌓아 둘 수 있다
+// 저장하는 쪽에서 PullEvents 로 꺼내 outbox 에 같은 트랜잭션으로 기록한다
+type Events struct {
+	pending []Event
+}
+
+func (e *Events) Record(event Event) {
+	e.pending = append(e.pending, event)
+}
+
+func (e *Events) PullEvents() []Event {
+	events := e.pending
+	e.pending = nil
+	return events
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	StatusPending = "pending"
+	StatusReady   = "ready"
+	StatusFailed  = "failed"
+)
+
+// Export 는 사용자 데이터 내보내기 요청 하나. 준비되면 Archive 에 ZIP 이 담긴다
+type Export struct {
+	ID          int64
+	UserID      uint
+	Status      string
+	Archive     []byte
+	Error       string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	// ExpiresAt 이 지나면 내려받을 수 없고 배치가 지운다
+	ExpiresAt *time.Time
+}
+
+func New(userID uint) *Export {
+	return &Export{UserID: userID, Status: StatusPending}
+}
+
+func (e *Export) Downloadable(now time.Time) bool {
+	return e.Status == StatusReady && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
+type Repository interface {
+	Create(ctx context.Context, export *Export) error
+	Find(ctx context.Context, id int64) (*Export, error)
+	// FindPending 은 사용자의 아직 만들어지지 않은 내보내기를 찾는다
+	FindPending(ctx context.Context, userID uint) (*Export, error)
+	MarkReady(ctx context.Context, id int64, archive []byte, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, id int64, reason string) error
+}
@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"module.resume/internal/domain"
+)
+
+const (
+	EmailChangePending   = "pending"
+	EmailChangeConfirmed = "confirmed"
+	// EmailChangeCancelled 는 확인 전에 취소(undo)되었거나 새 요청으로 대체된 상태
+	EmailChangeCancelled = "cancelled"
+	// EmailChangeUndone 은 확인된 뒤 예전 주소에서 되돌린 상태
+	EmailChangeUndone = "undone"
+)
+
+var errEmailChangeLink = domain.NotFound("link is invalid or has expired")
+
+// EmailChange 는 email 변경 요청. 새 주소로 보낸 토큰으로 확인하고, 예전 주소로 보낸 토큰으로 되돌린다
+// 토큰은 해시만 저장한다
+type EmailChange struct {
+	ID               int64
+	UserID           uint
+	OldEmail         string
+	NewEmail         string
+	ConfirmTokenHash string
+	UndoTokenHash    string
+	Status           string
+	CreatedAt        time.Time
+	// ExpiresAt 이 지나면 확인할 수 없다
+	ExpiresAt time.Time
+	// UndoExpiresAt 이 지나면 되돌릴 수 없다
+	UndoExpiresAt time.Time
+	CompletedAt   *time.Time
+}
+
+func NewEmailChange(u *User, newEmail, confirmTokenHash, undoTokenHash string, ttl, undoWindow time.Duration) *EmailChange {
+	now := time.Now()
+	return &EmailChange{
+		UserID:           u.ID,
+		OldEmail:         u.Email,
+		NewEmail:         newEmail,
+		ConfirmTokenHash: confirmTokenHash,
+		UndoTokenHash:    undoTokenHash,
+		Status:           EmailChangePending,
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(ttl),
+		UndoExpiresAt:    now.Add(undoWindow),
+	}
+}
+
+func (c *EmailChange) Confirm(now time.Time) error {
+	if c.Status != EmailChangePending || now.After(c.ExpiresAt) {
+		return errEmailChangeLink
+	}
+	c.Status = EmailChangeConfirmed
+	c.CompletedAt = &now
+	return nil
+}
+
+// Undo 는 요청을 취소하고, 이미 확인된 변경이었으면 email 을 되돌려야 하는지 알려준다
+func (c *EmailChange) Undo(now time.Time) (revert bool, err error) {
+	if now.After(c.UndoExpiresAt) {
+		return false, errEmailChangeLink
+	}
+	switch c.Status {
+	case EmailChangePending:
+		c.Status = EmailChangeCancelled
+	case EmailChangeConfirmed:
+		c.Status = EmailChangeUndone
+		revert = true
+	default:
+		return false, errEmailChangeLink
+	}
+	c.CompletedAt = &now
+	return revert, nil
+}
+
+type EmailChangeRepository interface {
+	Create(ctx context.Context, change *EmailChange) error
+	FindByConfirmToken(ctx context.Context, tokenHash string) (*EmailChange, error)
+	FindByUndoToken(ctx context.Context, tokenHash string) (*EmailChange, error)
+	// CancelPending 은 사용자의 확인 전 요청을 모두 취소한다. 새 요청을 만들기 전에 부른다
+	CancelPending(ctx context.Context, userID uint) error
+	// Transition 은 상태가 아직 from 일 때만 change 의 상태를 저장한다. 이미 바뀌었으면 NotFound
+	Transition(ctx context.Context, change *EmailChange, from string) error
+}
@@ -0,0 +1,61 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"module.resume/internal/domain"
+)
+
+func TestEmailChange(t *testing.T) {
+	newChange := func() *EmailChange {
+		return NewEmailChange(&User{ID: 1, Email: "old@example.com"}, "new@example.com", "confirm", "undo", time.Hour, 24*time.Hour)
+	}
+
+	t.Run("confirm then undo reverts", func(t *testing.T) {
+		c := newChange()
+		assert.Equal(t, "old@example.com", c.OldEmail)
+
+		assert.NoError(t, c.Confirm(time.Now()))
+		assert.Equal(t, EmailChangeConfirmed, c.Status)
+		assert.ErrorIs(t, c.Confirm(time.Now()), domain.ErrNotFound)
+
+		revert, err := c.Undo(time.Now())
+		assert.NoError(t, err)
+		assert.True(t, revert)
+		assert.Equal(t, EmailChangeUndone, c.Status)
+	})
+
+	t.Run("undo before confirm cancels", func(t *testing.T) {
+		c := newChange()
+
+		revert, err := c.Undo(time.Now())
+		assert.NoError(t, err)
+		assert.False(t, revert)
+		assert.Equal(t, EmailChangeCancelled, c.Status)
+		assert.ErrorIs(t, c.Confirm(time.Now()), domain.ErrNotFound)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		c := newChange()
+
+		assert.ErrorIs(t, c.Confirm(time.Now().Add(2*time.Hour)), domain.ErrNotFound)
+		_, err := c.Undo(time.Now().Add(48 * time.Hour))
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Equal(t, EmailChangePending, c.Status)
+	})
+}
+
+func TestUser_ChangeEmail(t *testing.T) {
+	u := &User{ID: 1, Email: "old@example.com"}
+	issuedAt := time.Now().Add(-time.Minute)
+
+	u.ChangeEmail("new@example.com")
+	assert.Equal(t, "new@example.com", u.Email)
+	assert.True(t, u.TokenRevoked(issuedAt))
+
+	u.RevertEmail("old@example.com")
+	assert.Equal(t, "old@example.com", u.Email)
+	assert.Len(t, u.PullEvents(), 2)
+}
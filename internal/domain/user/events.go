@@ -0,0 +1,81 @@
+package user
+
+import (
+	"strconv"
+	"time"
+)
+
+const aggregateType = "user"
+
+type Registered struct {
+	UserID uint      `json:"user_id"`
+	Email  string    `json:"email"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (Registered) EventName() string       { return "user.registered" }
+func (Registered) AggregateType() string   { return aggregateType }
+func (e Registered) AggregateID() string   { return strconv.FormatUint(uint64(e.UserID), 10) }
+func (e Registered) OccurredAt() time.Time { return e.At }
+
+type PasswordChanged struct {
+	UserID uint      `json:"user_id"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (PasswordChanged) EventName() string       { return "user.password_changed" }
+func (PasswordChanged) AggregateType() string   { return aggregateType }
+func (e PasswordChanged) AggregateID() string   { return strconv.FormatUint(uint64(e.UserID), 10) }
+func (e PasswordChanged) OccurredAt() time.Time { return e.At }
+
+type Deleted struct {
+	UserID uint      `json:"user_id"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (Deleted) EventName() string       { return "user.deleted" }
+func (Deleted) AggregateType() string   { return aggregateType }
+func (e Deleted) AggregateID() string   { return strconv.FormatUint(uint64(e.UserID), 10) }
+func (e Deleted) OccurredAt() time.Time { return e.At }
+
+type DeletionCancelled struct {
+	UserID uint      `json:"user_id"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (DeletionCancelled) EventName() string       { return "user.deletion_cancelled" }
+func (DeletionCancelled) AggregateType() string   { return aggregateType }
+func (e DeletionCancelled) AggregateID() string   { return strconv.FormatUint(uint64(e.UserID), 10) }
+func (e DeletionCancelled) OccurredAt() time.Time { return e.At }
+
+// Erased 는 유예 기간이 지나 개인정보가 익명화되었음을 알린다
+// 사용자 데이터를 따로 가진 서비스는 이 이벤트를 받아 자기 데이터를 지워야 한다
+type Erased struct {
+	UserID uint      `json:"user_id"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (Erased) EventName() string       { return "user.erased" }
+func (Erased) AggregateType() string   { return aggregateType }
+func (e Erased) AggregateID() string   { return strconv.FormatUint(uint64(e.UserID), 10) }
+func (e Erased) OccurredAt() time.Time { return e.At }
+
+type EmailChanged struct {
+	UserID uint      `json:"user_id"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (EmailChanged) EventName() string       { return "user.email_changed" }
+func (EmailChanged) AggregateType() string   { return aggregateType }
+func (e EmailChanged) AggregateID() string   { return strconv.FormatUint(uint64(e.UserID), 10) }
+func (e EmailChanged) OccurredAt() time.Time { return e.At }
+
+type EmailChangeReverted struct {
+	UserID uint      `json:"user_id"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (EmailChangeReverted) EventName() string       { return "user.email_change_reverted" }
+func (EmailChangeReverted) AggregateType() string   { return aggregateType }
+func (e EmailChangeReverted) AggregateID() string   { return strconv.FormatUint(uint64(e.UserID), 10) }
+func (e EmailChangeReverted) OccurredAt() time.Time { return e.At }
@@ -3,8 +3,22 @@ package user
 import "context"
 
 type Repository interface {
+	// FindByID, FindByEmail 은 트랜잭션 밖에서는 캐시에서 올 수 있고 그때는 비밀번호 해시가 비어 있다
+	FindByID(ctx context.Context, id uint) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
+	// FindCredentials 는 트랜잭션 밖에서 비밀번호를 확인할 때 쓴다. 캐시를 거치지 않고 해시까지 읽는다
+	FindCredentials(ctx context.Context, email string) (*User, error)
 	Save(ctx context.Context, user *User) (uint, error)
+	// Update 는 프로필(이름, 프로필 URL)을 통째로 저장한다. 빈 값도 그대로 쓴다
+	// email 은 확인 절차를 거쳐야 하므로 여기서 바꾸지 않고 UpdateEmail 로만 바꾼다
 	Update(ctx context.Context, user *User) (uint, error)
-	Delete(ctx context.Context, id uint) error
+	UpdatePassword(ctx context.Context, user *User) error
+	// UpdateEmail 은 email 과 토큰 무효화 시각만 바꾼다. oldEmail 은 바꾸기 전 주소
+	UpdateEmail(ctx context.Context, user *User, oldEmail string) error
+	UpdateAvatar(ctx context.Context, user *User) error
+	// Delete 는 탈퇴 시각과 토큰 무효화 시각을 저장한다 (soft delete)
+	Delete(ctx context.Context, user *User) error
+	// FindDeletedByEmail 은 아직 익명화되지 않은 탈퇴 계정을 찾는다
+	FindDeletedByEmail(ctx context.Context, email string) (*User, error)
+	Restore(ctx context.Context, user *User) error
 }
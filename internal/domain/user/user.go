@@ -3,19 +3,26 @@ package user
 import (
 	"time"
 
+	"module.resume/internal/domain"
 	"module.resume/internal/util"
 )
 
 type User struct {
+	domain.Events
+
 	ID           uint
 	Email        string
 	Name         string
 	Password     string
 	passwordHash string
 	ProfileUrl   string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	DeletedAt    *time.Time
+	// AvatarKey 는 업로드한 프로필 사진의 BlobStore key. 없으면 빈 문자열
+	AvatarKey string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+	// TokensInvalidBefore 이전에 발급된 토큰은 모두 무효다 (비밀번호 변경 등)
+	TokensInvalidBefore *time.Time
 }
 
 func NewUserForSave(email, name, plainPassword, profileUrl string) (*User, error) {
@@ -59,6 +66,72 @@ func Hydrate(id uint, email, name, hashedPassword string, createdAt, updatedAt t
 	}
 }
 
+// CompleteRegistration 은 저장으로 ID 가 정해진 뒤 가입 완료 이벤트를 남긴다
+func (u *User) CompleteRegistration() {
+	u.Record(Registered{UserID: u.ID, Email: u.Email, At: time.Now()})
+}
+
+// ChangePassword 는 새 비밀번호로 바꾸고 그 전에 발급된 토큰을 모두 무효로 만든다
+func (u *User) ChangePassword(plainPassword string) error {
+	hashedPassword, err := util.HashPassword(plainPassword)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	u.passwordHash = hashedPassword
+	u.TokensInvalidBefore = &now
+	u.Record(PasswordChanged{UserID: u.ID, At: now})
+	return nil
+}
+
+// ChangeEmail 은 확인된 새 주소로 바꾼다. 예전 주소로 받은 토큰이 남지 않도록 모든 토큰을 무효로 만든다
+func (u *User) ChangeEmail(newEmail string) {
+	now := time.Now()
+	u.Email = newEmail
+	u.TokensInvalidBefore = &now
+	u.Record(EmailChanged{UserID: u.ID, At: now})
+}
+
+// RevertEmail 은 예전 주소의 주인이 변경을 되돌린 경우. 계정을 빼앗긴 것일 수 있으므로 토큰도 모두 무효로 만든다
+func (u *User) RevertEmail(oldEmail string) {
+	now := time.Now()
+	u.Email = oldEmail
+	u.TokensInvalidBefore = &now
+	u.Record(EmailChangeReverted{UserID: u.ID, At: now})
+}
+
+// Delete 는 계정을 탈퇴 상태로 바꾸고 발급된 토큰을 모두 무효로 만든다
+// 개인정보는 유예 기간이 지난 뒤 배치에서 익명화한다
+func (u *User) Delete() {
+	now := time.Now()
+	u.DeletedAt = &now
+	u.TokensInvalidBefore = &now
+	u.Record(Deleted{UserID: u.ID, At: now})
+}
+
+// CancelDeletion 은 유예 기간 안에 탈퇴를 취소한다. 탈퇴 전에 발급된 토큰은 계속 무효다
+func (u *User) CancelDeletion(gracePeriod time.Duration) error {
+	if u.DeletedAt == nil {
+		return domain.Conflict("account is not scheduled for deletion")
+	}
+	if time.Since(*u.DeletedAt) > gracePeriod {
+		return domain.Conflict("deletion can no longer be cancelled")
+	}
+	u.DeletedAt = nil
+	u.Record(DeletionCancelled{UserID: u.ID, At: time.Now()})
+	return nil
+}
+
+// TokenRevoked 는 issuedAt 에 발급된 토큰이 무효화되었는지 확인한다
+// JWT 의 iat 는 밀리초 단위라서 무효화 시각도 밀리초 단위로 내려서 비교한다
+// 무효화와 같은 밀리초에 발급된 토큰은 어느 쪽이 먼저인지 알 수 없으므로 무효로 본다
+func (u *User) TokenRevoked(issuedAt time.Time) bool {
+	if u.TokensInvalidBefore == nil {
+		return false
+	}
+	return !issuedAt.After(u.TokensInvalidBefore.Truncate(time.Millisecond))
+}
+
 func (u *User) CheckPassword(plainPassword string) bool {
 	return util.CheckPasswordHash(plainPassword, u.passwordHash)
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"module.resume/internal/domain"
 )
 
 func TestNewUserForSave(t *testing.T) {
@@ -93,3 +94,47 @@ func TestUser_SetPasswordHash(t *testing.T) {
 	user.SetPasswordHash(hash)
 	assert.Equal(t, hash, user.PasswordHash())
 }
+
+func TestUser_TokenRevoked(t *testing.T) {
+	revokedAt := time.Date(2024, 5, 1, 10, 0, 0, 700_400_000, time.UTC)
+	u := &User{ID: 1, TokensInvalidBefore: &revokedAt}
+
+	assert.False(t, (&User{ID: 1}).TokenRevoked(revokedAt))
+	// 같은 초에 무효화 전에 발급된 토큰도 무효다
+	assert.True(t, u.TokenRevoked(revokedAt.Truncate(time.Second)))
+	assert.True(t, u.TokenRevoked(revokedAt.Add(-time.Millisecond)))
+	assert.True(t, u.TokenRevoked(revokedAt.Truncate(time.Millisecond)))
+	// 같은 초라도 무효화 뒤에 발급된 토큰은 유효하다
+	assert.False(t, u.TokenRevoked(revokedAt.Add(time.Millisecond).Truncate(time.Millisecond)))
+}
+
+func TestUser_DeleteAndCancel(t *testing.T) {
+	grace := 24 * time.Hour
+
+	t.Run("delete revokes tokens and cancel restores", func(t *testing.T) {
+		u := &User{ID: 1}
+		issuedAt := time.Now().Add(-time.Minute)
+
+		u.Delete()
+
+		assert.NotNil(t, u.DeletedAt)
+		assert.True(t, u.TokenRevoked(issuedAt))
+
+		assert.NoError(t, u.CancelDeletion(grace))
+		assert.Nil(t, u.DeletedAt)
+		assert.True(t, u.TokenRevoked(issuedAt))
+		assert.Len(t, u.PullEvents(), 2)
+	})
+
+	t.Run("cancel after grace period", func(t *testing.T) {
+		deletedAt := time.Now().Add(-grace - time.Minute)
+		u := &User{ID: 1, DeletedAt: &deletedAt}
+
+		assert.ErrorIs(t, u.CancelDeletion(grace), domain.ErrConflict)
+		assert.NotNil(t, u.DeletedAt)
+	})
+
+	t.Run("cancel without deletion", func(t *testing.T) {
+		assert.ErrorIs(t, (&User{ID: 1}).CancelDeletion(grace), domain.ErrConflict)
+	})
+}
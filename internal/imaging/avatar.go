@@ -0,0 +1,122 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"module.resume/internal/domain"
+)
+
+// 디코딩 전에 크기를 확인해 작은 파일로 큰 메모리를 쓰게 하는 이미지(decompression bomb)를 막는다
+const maxPixels = 4096 * 4096
+
+const jpegQuality = 85
+
+var (
+	errUnsupportedImage = domain.Validation("request validation failed",
+		domain.FieldError{Field: "avatar", Message: "must be a JPEG, PNG or GIF image"})
+	errInvalidImage = domain.Validation("request validation failed",
+		domain.FieldError{Field: "avatar", Message: "is not a valid image"})
+	errImageTooLarge = domain.Validation("request validation failed",
+		domain.FieldError{Field: "avatar", Message: "image dimensions are too large"})
+)
+
+// Avatar 는 업로드된 이미지를 가운데 기준 정사각형으로 자르고 size x size 로 줄인다
+// 형식은 내용으로 판단하고(클라이언트가 보낸 Content-Type 은 믿지 않는다) 다시 인코딩하므로
+// EXIF(위치 정보 등) 같은 메타데이터는 남지 않는다. JPEG 는 JPEG 로, 나머지는 투명도를 살려 PNG 로 만든다
+func Avatar(data []byte, size int) ([]byte, string, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return nil, "", errUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", errInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, "", errImageTooLarge
+	}
+	src, err := decode(contentType, data)
+	if err != nil {
+		return nil, "", errInvalidImage
+	}
+
+	resized := resize(squareCrop(src), size)
+
+	var out bytes.Buffer
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&out, resized, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		contentType = "image/png"
+		err = png.Encode(&out, resized)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Bytes(), contentType, nil
+}
+
+func decode(contentType string, data []byte) (image.Image, error) {
+	r := bytes.NewReader(data)
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.Decode(r)
+	case "image/png":
+		return png.Decode(r)
+	default:
+		// 움직이는 GIF 는 첫 프레임만 쓴다
+		return gif.Decode(r)
+	}
+}
+
+// squareCrop 은 짧은 변 길이의 정사각형을 가운데에서 잘라 RGBA 로 돌려준다
+func squareCrop(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	origin := image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2)
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), src, origin, draw.Src)
+	return dst
+}
+
+// resize 는 정사각형 src 를 size x size 로 바꾼다
+// 줄일 때는 대상 픽셀이 덮는 원본 영역의 평균(box filter)을 써서 계단 현상을 줄인다
+func resize(src *image.RGBA, size int) *image.RGBA {
+	side := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := span(y, side, size)
+		for x := 0; x < size; x++ {
+			x0, x1 := span(x, side, size)
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r, g, b, a = r+uint32(p[0]), g+uint32(p[1]), b+uint32(p[2]), a+uint32(p[3])
+					n++
+				}
+			}
+			i := y*dst.Stride + x*4
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3] = uint8(r/n), uint8(g/n), uint8(b/n), uint8(a/n)
+		}
+	}
+	return dst
+}
+
+// span 은 대상 좌표 i 가 덮는 원본 범위 [from, to). 키울 때도 최소 한 픽셀을 돌려준다
+func span(i, side, size int) (int, int) {
+	from, to := i*side/size, (i+1)*side/size
+	if to <= from {
+		to = from + 1
+	}
+	return from, min(to, side)
+}
@@ -0,0 +1,80 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+)
+
+func testImage(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// withExif 는 JPEG 의 SOI 바로 뒤에 EXIF(APP1) 세그먼트를 넣는다
+func withExif(t *testing.T, jpegData []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), []byte("GPS 37.5665 126.9780")...)
+	segment := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+	require.Equal(t, []byte{0xFF, 0xD8}, jpegData[:2])
+	out := append([]byte{0xFF, 0xD8}, segment...)
+	out = append(out, payload...)
+	return append(out, jpegData[2:]...)
+}
+
+func TestAvatar(t *testing.T) {
+	t.Run("jpeg is cropped, resized and stripped", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, jpeg.Encode(&buf, testImage(300, 200), nil))
+		data := withExif(t, buf.Bytes())
+		require.True(t, bytes.Contains(data, []byte("Exif")))
+
+		out, contentType, err := Avatar(data, 64)
+
+		require.NoError(t, err)
+		assert.Equal(t, "image/jpeg", contentType)
+		assert.False(t, bytes.Contains(out, []byte("Exif")))
+		assert.False(t, bytes.Contains(out, []byte("GPS")))
+		config, err := jpeg.DecodeConfig(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 64, config.Width)
+		assert.Equal(t, 64, config.Height)
+	})
+
+	t.Run("png is upscaled as png", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, testImage(20, 40)))
+
+		out, contentType, err := Avatar(buf.Bytes(), 64)
+
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+		config, err := png.DecodeConfig(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 64, config.Width)
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		_, _, err := Avatar([]byte("<svg xmlns='http://www.w3.org/2000/svg'></svg>"), 64)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+
+	t.Run("truncated image", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, testImage(20, 20)))
+
+		_, _, err := Avatar(buf.Bytes()[:60], 64)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
@@ -0,0 +1,127 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+)
+
+const blobNotFound = "file not found"
+
+// FSStore 는 파일을 로컬 디렉터리에 둔다. 인스턴스가 하나이거나 공유 볼륨이 있을 때 쓴다
+// content type 은 따로 저장하지 않고 확장자로 정한다
+type FSStore struct {
+	root string
+}
+
+func NewFSStore(root string) (*FSStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+	return &FSStore{root: root}, nil
+}
+
+var _ application.BlobStore = (*FSStore)(nil)
+
+// Put 은 임시 파일에 쓴 뒤 rename 해서 읽는 쪽이 쓰다 만 파일을 보지 않게 한다
+func (s *FSStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (s *FSStore) Get(ctx context.Context, key string) (*application.Blob, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.NotFound(blobNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &application.Blob{Body: f, ContentType: contentType, Size: info.Size()}, nil
+}
+
+func (s *FSStore) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// DeletePrefix 는 prefix 가 디렉터리("avatars/1/")면 디렉터리째 지운다
+func (s *FSStore) DeletePrefix(ctx context.Context, prefix string) error {
+	dir, base := path.Split(prefix)
+	root := s.root
+	if dir != "" {
+		var err error
+		if root, err = s.path(strings.TrimSuffix(dir, "/")); err != nil {
+			return err
+		}
+	}
+	entries, err := os.ReadDir(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), base) {
+			if err := os.RemoveAll(filepath.Join(root, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// path 는 key 를 root 아래 경로로 바꾼다. root 밖을 가리키는 key 는 거부한다
+func (s *FSStore) path(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
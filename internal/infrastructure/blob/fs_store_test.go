@@ -0,0 +1,39 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+)
+
+func TestFSStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFSStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "avatars/1/a.jpg", "image/jpeg", []byte("jpeg")))
+	require.NoError(t, store.Put(ctx, "avatars/2/b.png", "image/png", []byte("png")))
+
+	blob, err := store.Get(ctx, "avatars/1/a.jpg")
+	require.NoError(t, err)
+	data, err := io.ReadAll(blob.Body)
+	blob.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("jpeg"), data)
+	assert.Equal(t, "image/jpeg", blob.ContentType)
+	assert.Equal(t, int64(4), blob.Size)
+
+	require.NoError(t, store.DeletePrefix(ctx, "avatars/1/"))
+	_, err = store.Get(ctx, "avatars/1/a.jpg")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = store.Get(ctx, "avatars/2/b.png")
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Delete(ctx, "avatars/2/missing.png"))
+	assert.Error(t, store.Put(ctx, "../escape.png", "image/png", []byte("png")))
+	assert.Error(t, store.Put(ctx, "/abs.png", "image/png", []byte("png")))
+}
@@ -0,0 +1,167 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+)
+
+// S3Store 는 S3 호환 저장소(AWS S3, MinIO 등)에 파일을 둔다
+// SDK 없이 REST API 를 직접 부르고, 버킷은 path-style(endpoint/bucket/key)로 가리킨다
+type S3Store struct {
+	endpoint *url.URL
+	bucket   string
+	signer   sigV4
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewS3Store(endpoint, region, bucket, accessKey, secretKey string, client *http.Client) (*S3Store, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	return &S3Store{
+		endpoint: u,
+		bucket:   bucket,
+		signer:   sigV4{accessKey: accessKey, secretKey: secretKey, region: region, service: "s3"},
+		client:   client,
+		now:      time.Now,
+	}, nil
+}
+
+var _ application.BlobStore = (*S3Store)(nil)
+
+func (s *S3Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, nil, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp, http.MethodPut, key)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (*application.Blob, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &application.Blob{Body: resp.Body, ContentType: resp.Header.Get("Content-Type"), Size: resp.ContentLength}, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, domain.NotFound(blobNotFound)
+	default:
+		defer resp.Body.Close()
+		return nil, responseError(resp, http.MethodGet, key)
+	}
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return responseError(resp, http.MethodDelete, key)
+	}
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// DeletePrefix 는 ListObjectsV2 로 한 페이지씩 읽으며 지운다
+func (s *S3Store) DeletePrefix(ctx context.Context, prefix string) error {
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	for {
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil, "")
+		if err != nil {
+			return err
+		}
+		var result listBucketResult
+		if resp.StatusCode == http.StatusOK {
+			err = xml.NewDecoder(resp.Body).Decode(&result)
+		} else {
+			err = responseError(resp, http.MethodGet, prefix)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, object := range result.Contents {
+			if err := s.Delete(ctx, object.Key); err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// do 는 key 가 비어 있으면 버킷 자체에 요청한다
+func (s *S3Store) do(ctx context.Context, method, key string, query url.Values, body []byte, contentType string) (*http.Response, error) {
+	path := s.endpoint.Path + "/" + uriEncode(s.bucket, true)
+	if key != "" {
+		path += "/" + uriEncode(key, false)
+	}
+	target := *s.endpoint
+	target.Path, target.RawPath = "", ""
+	raw := target.String() + path
+	if len(query) > 0 {
+		pairs := make([]string, 0, len(query))
+		for k, values := range query {
+			for _, v := range values {
+				pairs = append(pairs, uriEncode(k, true)+"="+uriEncode(v, true))
+			}
+		}
+		raw += "?" + strings.Join(pairs, "&")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, raw, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	// UNSIGNED-PAYLOAD 대신 본문 해시를 넣어 http 엔드포인트에서도 본문이 검증되게 한다
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	s.signer.sign(req, path, payloadHash, s.now())
+	return s.client.Do(req)
+}
+
+// responseError 는 S3 에러 응답의 Code 를 메시지에 넣는다
+func responseError(resp *http.Response, method, key string) error {
+	var body struct {
+		Code string `xml:"Code"`
+	}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&body)
+	if body.Code != "" {
+		return fmt.Errorf("s3 %s %q: %s (%s)", method, key, resp.Status, body.Code)
+	}
+	return fmt.Errorf("s3 %s %q: %s", method, key, resp.Status)
+}
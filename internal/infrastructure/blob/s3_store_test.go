@@ -0,0 +1,147 @@
+package blob
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+)
+
+// AWS SigV4 테스트 모음의 get-vanilla
+func TestSigV4_Vanilla(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	signer := sigV4{accessKey: "AKIDEXAMPLE", secretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", region: "us-east-1", service: "service"}
+
+	signer.sign(req, "/", sha256Hex(nil), time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+// fakeS3 는 MinIO 대신 쓰는 최소한의 S3 서버. 받은 요청의 서명을 다시 계산해 확인한다
+type fakeS3 struct {
+	mu      sync.Mutex
+	signer  sigV4
+	bucket  string
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	contentType string
+	data        []byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.verify(r) {
+		http.Error(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>", http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucketPath := "/" + f.bucket
+	if r.URL.Path == bucketPath && r.Method == http.MethodGet {
+		f.list(w, r.URL.Query().Get("prefix"))
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, bucketPath+"/")
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		if sha256Hex(data) != r.Header.Get("X-Amz-Content-Sha256") {
+			http.Error(w, "<Error><Code>XAmzContentSHA256Mismatch</Code></Error>", http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = fakeObject{contentType: r.Header.Get("Content-Type"), data: data}
+	case http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		_, _ = w.Write(object.data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) verify(r *http.Request) bool {
+	date, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	got := r.Header.Get("Authorization")
+	clone := r.Clone(context.Background())
+	f.signer.sign(clone, r.URL.EscapedPath(), r.Header.Get("X-Amz-Content-Sha256"), date)
+	return got != "" && got == clone.Header.Get("Authorization")
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, prefix string) {
+	type content struct {
+		Key string `xml:"Key"`
+	}
+	result := struct {
+		XMLName  xml.Name  `xml:"ListBucketResult"`
+		Contents []content `xml:"Contents"`
+	}{}
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			result.Contents = append(result.Contents, content{Key: key})
+		}
+	}
+	sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{
+		signer:  sigV4{accessKey: "minio", secretKey: "minio-secret", region: "us-east-1", service: "s3"},
+		bucket:  "resume",
+		objects: map[string]fakeObject{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Store(server.URL, "us-east-1", "resume", "minio", "minio-secret", server.Client())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "avatars/1/a b.jpg", "image/jpeg", []byte("jpeg")))
+	require.NoError(t, store.Put(ctx, "avatars/1/c.png", "image/png", []byte("png")))
+	require.NoError(t, store.Put(ctx, "avatars/2/d.png", "image/png", []byte("png")))
+
+	blob, err := store.Get(ctx, "avatars/1/a b.jpg")
+	require.NoError(t, err)
+	data, err := io.ReadAll(blob.Body)
+	blob.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("jpeg"), data)
+	assert.Equal(t, "image/jpeg", blob.ContentType)
+
+	require.NoError(t, store.DeletePrefix(ctx, "avatars/1/"))
+	_, err = store.Get(ctx, "avatars/1/c.png")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Len(t, fake.objects, 1)
+
+	t.Run("wrong credentials", func(t *testing.T) {
+		wrong, err := NewS3Store(server.URL, "us-east-1", "resume", "minio", "wrong", server.Client())
+		require.NoError(t, err)
+
+		err = wrong.Put(ctx, "avatars/3/e.png", "image/png", []byte("png"))
+		assert.ErrorContains(t, err, "SignatureDoesNotMatch")
+	})
+}
@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"module.resume/internal/application"
+)
+
+const defaultMemoryCacheCapacity = 10000
+
+type memoryEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// memoryCache 는 단일 노드/개발용 LRU + TTL 캐시
+type memoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+	now      func() time.Time
+}
+
+func NewMemoryCache(capacity int) *memoryCache {
+	if capacity <= 0 {
+		capacity = defaultMemoryCacheCapacity
+	}
+	return &memoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(key, toString(value), expiration)
+	return nil
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.get(key)
+	if !ok {
+		return "", application.ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+func (m *memoryCache) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := m.items[key]; ok {
+			m.removeElement(el)
+		}
+	}
+	return nil
+}
+
+func (m *memoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.get(key)
+	return ok, nil
+}
+
+func (m *memoryCache) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.get(key)
+	if !ok {
+		m.set(key, "1", expiration)
+		return 1, nil
+	}
+
+	n, err := strconv.ParseInt(entry.value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value of %q is not an integer", key)
+	}
+	n++
+	entry.value = strconv.FormatInt(n, 10)
+	return n, nil
+}
+
+func (m *memoryCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.get(key); ok {
+		return false, nil
+	}
+	m.set(key, toString(value), expiration)
+	return true, nil
+}
+
+func (m *memoryCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if entry, ok := m.get(key); ok {
+			result[key] = entry.value
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+func (m *memoryCache) set(key, value string, expiration time.Duration) {
+	entry := &memoryEntry{key: key, value: value}
+	if expiration > 0 {
+		entry.expiresAt = m.now().Add(expiration)
+	}
+
+	if el, ok := m.items[key]; ok {
+		el.Value = entry
+		m.ll.MoveToFront(el)
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(entry)
+	for m.ll.Len() > m.capacity {
+		m.removeElement(m.ll.Back())
+	}
+}
+
+// get 은 만료된 항목을 지우고, 찾은 항목을 가장 최근 사용으로 옮긴다
+func (m *memoryCache) get(key string) (*memoryEntry, bool) {
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*memoryEntry)
+	if entry.expired(m.now()) {
+		m.removeElement(el)
+		return nil, false
+	}
+	m.ll.MoveToFront(el)
+	return entry, true
+}
+
+func (m *memoryCache) removeElement(el *list.Element) {
+	m.ll.Remove(el)
+	delete(m.items, el.Value.(*memoryEntry).key)
+}
+
+// redis 가 값을 문자열로 저장하는 방식과 맞추기
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"module.resume/internal/application"
+)
+
+func TestMemoryCache_SetGet(t *testing.T) {
+	c := NewMemoryCache(10)
+	ctx := context.Background()
+
+	t.Run("hit", func(t *testing.T) {
+		assert.NoError(t, c.Set(ctx, "blocklist:token", "true", time.Hour))
+
+		val, err := c.Get(ctx, "blocklist:token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "true", val)
+	})
+
+	t.Run("miss", func(t *testing.T) {
+		val, err := c.Get(ctx, "unknown")
+
+		assert.ErrorIs(t, err, application.ErrCacheMiss)
+		assert.Empty(t, val)
+	})
+
+	t.Run("non string value", func(t *testing.T) {
+		assert.NoError(t, c.Set(ctx, "count", 42, 0))
+
+		val, err := c.Get(ctx, "count")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "42", val)
+	})
+}
+
+func TestMemoryCache_Expiration(t *testing.T) {
+	c := NewMemoryCache(10)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.NoError(t, c.Set(ctx, "short", "v", time.Minute))
+	assert.NoError(t, c.Set(ctx, "forever", "v", 0))
+
+	now = now.Add(2 * time.Minute)
+
+	val, err := c.Get(ctx, "short")
+	assert.ErrorIs(t, err, application.ErrCacheMiss)
+	assert.Empty(t, val)
+
+	val, err = c.Get(ctx, "forever")
+	assert.NoError(t, err)
+	assert.Equal(t, "v", val)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestMemoryCache_Eviction(t *testing.T) {
+	c := NewMemoryCache(3)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, c.Set(ctx, fmt.Sprintf("key%d", i), i, 0))
+	}
+
+	// key0 을 최근에 사용해서 key1 이 가장 오래된 항목이 됨
+	_, _ = c.Get(ctx, "key0")
+	assert.NoError(t, c.Set(ctx, "key3", 3, 0))
+
+	assert.Equal(t, 3, c.Len())
+	val, _ := c.Get(ctx, "key1")
+	assert.Empty(t, val)
+	val, _ = c.Get(ctx, "key0")
+	assert.Equal(t, "0", val)
+	val, _ = c.Get(ctx, "key3")
+	assert.Equal(t, "3", val)
+}
+
+func TestMemoryCache_DeleteExists(t *testing.T) {
+	c := NewMemoryCache(10)
+	ctx := context.Background()
+	assert.NoError(t, c.Set(ctx, "a", "1", 0))
+	assert.NoError(t, c.Set(ctx, "b", "2", 0))
+
+	exists, err := c.Exists(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.NoError(t, c.Delete(ctx, "a", "b", "missing"))
+
+	exists, err = c.Exists(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestMemoryCache_Incr(t *testing.T) {
+	c := NewMemoryCache(10)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	t.Run("ttl is set only on create", func(t *testing.T) {
+		n, err := c.Incr(ctx, "login:attempts", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		now = now.Add(30 * time.Second)
+		n, err = c.Incr(ctx, "login:attempts", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+
+		now = now.Add(31 * time.Second)
+		n, err = c.Incr(ctx, "login:attempts", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	})
+
+	t.Run("not an integer", func(t *testing.T) {
+		assert.NoError(t, c.Set(ctx, "text", "abc", 0))
+
+		_, err := c.Incr(ctx, "text", 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMemoryCache_SetNX(t *testing.T) {
+	c := NewMemoryCache(10)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	ok, err := c.SetNX(ctx, "lock", "owner-1", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = c.SetNX(ctx, "lock", "owner-2", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	ok, err = c.SetNX(ctx, "lock", "owner-2", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	val, _ := c.Get(ctx, "lock")
+	assert.Equal(t, "owner-2", val)
+}
+
+func TestMemoryCache_MGet(t *testing.T) {
+	c := NewMemoryCache(10)
+	ctx := context.Background()
+	assert.NoError(t, c.Set(ctx, "a", "1", 0))
+	assert.NoError(t, c.Set(ctx, "b", "2", 0))
+
+	values, err := c.MGet(ctx, "a", "b", "c")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, values)
+}
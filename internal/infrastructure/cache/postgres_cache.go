@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"module.resume/internal/application"
+)
+
+type cacheEntry struct {
+	Key       string     `gorm:"column:key;primaryKey"`
+	Value     string     `gorm:"column:value;not null"`
+	ExpiresAt *time.Time `gorm:"column:expires_at;index"`
+}
+
+func (cacheEntry) TableName() string {
+	return "cache_entry"
+}
+
+// postgresCache 는 redis 없이 여러 노드가 캐시를 공유해야 할 때 사용
+// cache_entry 테이블은 마이그레이션으로 만든다
+type postgresCache struct {
+	db *gorm.DB
+}
+
+func NewPostgresCache(db *gorm.DB) *postgresCache {
+	return &postgresCache{db: db}
+}
+
+func (p *postgresCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	entry := &cacheEntry{Key: key, Value: toString(value)}
+	if expiration > 0 {
+		expiresAt := time.Now().Add(expiration)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	return p.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "expires_at"}),
+	}).Create(entry).Error
+}
+
+func (p *postgresCache) Get(ctx context.Context, key string) (string, error) {
+	entry := &cacheEntry{}
+	err := p.db.WithContext(ctx).
+		Where("key = ? AND (expires_at IS NULL OR expires_at > ?)", key, time.Now()).
+		Take(entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", application.ErrCacheMiss
+	}
+	if err != nil {
+		return "", err
+	}
+	return entry.Value, nil
+}
+
+func (p *postgresCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return p.db.WithContext(ctx).Where("key IN ?", keys).Delete(&cacheEntry{}).Error
+}
+
+func (p *postgresCache) Exists(ctx context.Context, key string) (bool, error) {
+	var count int64
+	err := p.db.WithContext(ctx).Model(&cacheEntry{}).
+		Where("key = ? AND (expires_at IS NULL OR expires_at > ?)", key, time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// 만료된 행은 없는 키로 보고 1 부터 다시 센다
+func (p *postgresCache) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	now := time.Now()
+	var expiresAt *time.Time
+	if expiration > 0 {
+		t := now.Add(expiration)
+		expiresAt = &t
+	}
+
+	var value int64
+	err := p.db.WithContext(ctx).Raw(`
+		INSERT INTO cache_entry (key, value, expires_at) VALUES (@key, '1', @expiresAt)
+		ON CONFLICT (key) DO UPDATE SET
+			value = CASE WHEN cache_entry.expires_at <= @now THEN '1'
+				ELSE (cache_entry.value::BIGINT + 1)::TEXT END,
+			expires_at = CASE WHEN cache_entry.expires_at <= @now THEN EXCLUDED.expires_at
+				ELSE cache_entry.expires_at END
+		RETURNING value::BIGINT`,
+		map[string]interface{}{"key": key, "expiresAt": expiresAt, "now": now},
+	).Scan(&value).Error
+	return value, err
+}
+
+func (p *postgresCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	now := time.Now()
+	entry := &cacheEntry{Key: key, Value: toString(value)}
+	if expiration > 0 {
+		expiresAt := now.Add(expiration)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	// 이미 있는 키라도 만료된 경우에는 덮어쓴다
+	result := p.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "cache_entry.expires_at <= ?", Vars: []interface{}{now}},
+		}},
+	}).Create(entry)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (p *postgresCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	result := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	var entries []cacheEntry
+	err := p.db.WithContext(ctx).
+		Where("key IN ? AND (expires_at IS NULL OR expires_at > ?)", keys, time.Now()).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		result[entry.Key] = entry.Value
+	}
+	return result, nil
+}
+
+func (p *postgresCache) DeleteExpired(ctx context.Context) (int64, error) {
+	result := p.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&cacheEntry{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+	"module.resume/internal/infrastructure/persistence/migrations"
+)
+
+// TEST_DATABASE_URL 이 있을 때만 실제 Postgres 에 대해 실행한다
+func testPostgresCache(t *testing.T) (*postgresCache, *gorm.DB) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := migrations.New(sqlDB, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return NewPostgresCache(db), db
+}
+
+func TestPostgresCache(t *testing.T) {
+	c, db := testPostgresCache(t)
+	ctx := context.Background()
+	prefix := "test-" + time.Now().Format("150405.000000") + ":"
+	t.Cleanup(func() { db.Exec(`DELETE FROM cache_entry WHERE key LIKE ?`, prefix+"%") })
+
+	t.Run("get miss", func(t *testing.T) {
+		_, err := c.Get(ctx, prefix+"missing")
+		assert.ErrorIs(t, err, application.ErrCacheMiss)
+	})
+
+	t.Run("set and get with ttl expiry", func(t *testing.T) {
+		key := prefix + "ttl"
+		require.NoError(t, c.Set(ctx, key, "v", 100*time.Millisecond))
+
+		value, err := c.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, "v", value)
+
+		time.Sleep(150 * time.Millisecond)
+		_, err = c.Get(ctx, key)
+		assert.ErrorIs(t, err, application.ErrCacheMiss)
+		exists, err := c.Exists(ctx, key)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("setnx", func(t *testing.T) {
+		key := prefix + "nx"
+		ok, err := c.SetNX(ctx, key, "first", 100*time.Millisecond)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = c.SetNX(ctx, key, "second", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		// 만료된 키는 덮어쓴다
+		time.Sleep(150 * time.Millisecond)
+		ok, err = c.SetNX(ctx, key, "third", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		value, err := c.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, "third", value)
+	})
+
+	t.Run("incr restarts after ttl", func(t *testing.T) {
+		key := prefix + "counter"
+		for want := int64(1); want <= 3; want++ {
+			n, err := c.Incr(ctx, key, 100*time.Millisecond)
+			require.NoError(t, err)
+			assert.Equal(t, want, n)
+		}
+
+		time.Sleep(150 * time.Millisecond)
+		n, err := c.Incr(ctx, key, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	})
+
+	t.Run("mget skips missing and expired keys", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, prefix+"a", "1", 0))
+		require.NoError(t, c.Set(ctx, prefix+"b", "2", time.Minute))
+		require.NoError(t, c.Set(ctx, prefix+"old", "3", time.Millisecond))
+		time.Sleep(10 * time.Millisecond)
+
+		values, err := c.MGet(ctx, prefix+"a", prefix+"b", prefix+"old", prefix+"none")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{prefix + "a": "1", prefix + "b": "2"}, values)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, prefix+"d1", "1", 0))
+		require.NoError(t, c.Set(ctx, prefix+"d2", "2", 0))
+		require.NoError(t, c.Delete(ctx, prefix+"d1", prefix+"d2"))
+
+		values, err := c.MGet(ctx, prefix+"d1", prefix+"d2")
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"module.resume/internal/application"
+	"module.resume/internal/infrastructure/logging"
+)
+
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// readThrough 는 캐시 데코레이터 리포지토리들이 공통으로 쓰는 조회 로직
+// 같은 키에 대한 동시 miss 는 singleflight 로 한 번만 원본을 조회한다
+type readThrough struct {
+	cache  application.Cache
+	ttl    time.Duration
+	group  singleflight.Group
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func newReadThrough(cache application.Cache, ttl time.Duration) *readThrough {
+	return &readThrough{cache: cache, ttl: ttl}
+}
+
+func (r *readThrough) get(ctx context.Context, key string, load func(ctx context.Context) (string, error)) (string, error) {
+	val, err := r.cache.Get(ctx, key)
+	if err == nil {
+		r.hits.Add(1)
+		return val, nil
+	}
+	r.misses.Add(1)
+
+	// 캐시 장애가 원본 조회까지 막으면 안 되므로 miss 가 아닌 에러도 원본으로 넘어간다
+	// 결과를 기다리는 다른 호출자도 있으므로 처음 호출자가 취소해도 조회는 계속한다
+	v, err, _ := r.group.Do(key, func() (interface{}, error) {
+		ctx := context.WithoutCancel(ctx)
+		val, err := load(ctx)
+		if err != nil {
+			return "", err
+		}
+		_ = r.cache.Set(ctx, key, val, r.ttl)
+		return val, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// 무효화에 실패하면 TTL 이 지날 때까지 예전 값이 보이므로 에러를 남긴다
+func (r *readThrough) invalidate(ctx context.Context, keys ...string) {
+	if err := r.cache.Delete(ctx, keys...); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "cache invalidation failed", "keys", keys, "error", err)
+	}
+}
+
+func (r *readThrough) lookup(ctx context.Context, key string) (string, bool) {
+	val, err := r.cache.Get(ctx, key)
+	if err != nil {
+		return "", false
+	}
+	return val, true
+}
+
+func (r *readThrough) stats() Stats {
+	return Stats{Hits: r.hits.Load(), Misses: r.misses.Load()}
+}
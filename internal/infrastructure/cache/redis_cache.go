@@ -2,17 +2,32 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"module.resume/internal/application"
 )
 
+// INCR 과 만료시간 설정을 한 번에 처리하기 위한 스크립트
+var incrScript = redis.NewScript(`
+local v = redis.call('INCR', KEYS[1])
+if v == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return v
+`)
+
+// 클러스터에서는 여러 키 명령이 서로 다른 슬롯에 걸리면 CROSSSLOT 으로 실패한다
+// 그래서 클러스터일 때는 키마다 명령을 보내고 파이프라인으로 묶는다. 파이프라인은 노드별로 나뉘어 전송된다
 type redisCache struct {
-	client *redis.Client
+	client  redis.UniversalClient
+	cluster bool
 }
 
-func NewRedisCache(client *redis.Client) *redisCache {
-	return &redisCache{client: client}
+func NewRedisCache(client redis.UniversalClient) *redisCache {
+	_, cluster := client.(*redis.ClusterClient)
+	return &redisCache{client: client, cluster: cluster}
 }
 
 func (r *redisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
@@ -20,5 +35,86 @@ func (r *redisCache) Set(ctx context.Context, key string, value interface{}, exp
 }
 
 func (r *redisCache) Get(ctx context.Context, key string) (string, error) {
-	return r.client.Get(ctx, key).Result()
+	val, err := r.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", application.ErrCacheMiss
+	}
+	return val, err
+}
+
+func (r *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if r.cluster {
+		_, err := r.client.Pipelined(ctx, func(p redis.Pipeliner) error {
+			for _, key := range keys {
+				p.Del(ctx, key)
+			}
+			return nil
+		})
+		return err
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *redisCache) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *redisCache) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	return incrScript.Run(ctx, r.client, []string{key}, expiration.Milliseconds()).Int64()
+}
+
+func (r *redisCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
+func (r *redisCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	result := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+	if r.cluster {
+		return r.mgetPerKey(ctx, keys, result)
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		if s, ok := v.(string); ok {
+			result[keys[i]] = s
+		}
+	}
+	return result, nil
+}
+
+func (r *redisCache) mgetPerKey(ctx context.Context, keys []string, result map[string]string) (map[string]string, error) {
+	cmds := make([]*redis.StringCmd, len(keys))
+	_, err := r.client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = p.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	for i, cmd := range cmds {
+		val, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[keys[i]] = val
+	}
+	return result, nil
 }
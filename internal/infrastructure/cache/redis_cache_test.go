@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClusterNode 는 모든 슬롯을 맡은 단일 노드 클러스터를 흉내 낸다
+// 실제 클러스터처럼 여러 키를 받는 DEL/MGET 은 CROSSSLOT 으로 거절한다
+type fakeClusterNode struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func newFakeClusterNode(t *testing.T) *fakeClusterNode {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	n := &fakeClusterNode{ln: ln, data: map[string]string{}}
+	t.Cleanup(func() { ln.Close() })
+	go n.serve()
+	return n
+}
+
+func (n *fakeClusterNode) serve() {
+	for {
+		conn, err := n.ln.Accept()
+		if err != nil {
+			return
+		}
+		go n.handle(conn)
+	}
+}
+
+func (n *fakeClusterNode) handle(conn net.Conn) {
+	defer conn.Close()
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		n.reply(w, args)
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (n *fakeClusterNode) reply(w *bufio.Writer, args []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	cmd, keys := strings.ToUpper(args[0]), args[1:]
+	switch {
+	case cmd == "CLUSTER" && len(keys) == 1 && strings.EqualFold(keys[0], "slots"):
+		host, port, _ := net.SplitHostPort(n.ln.Addr().String())
+		p, _ := strconv.Atoi(port)
+		fmt.Fprintf(w, "*1\r\n*3\r\n:0\r\n:16383\r\n*3\r\n$%d\r\n%s\r\n:%d\r\n$4\r\nnode\r\n", len(host), host, p)
+	case (cmd == "DEL" || cmd == "MGET") && len(keys) > 1:
+		w.WriteString("-CROSSSLOT Keys in request don't hash to the same slot\r\n")
+	case cmd == "SET" && len(keys) >= 2:
+		n.data[keys[0]] = keys[1]
+		w.WriteString("+OK\r\n")
+	case cmd == "GET" && len(keys) == 1:
+		if v, ok := n.data[keys[0]]; ok {
+			fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+		} else {
+			w.WriteString("$-1\r\n")
+		}
+	case cmd == "DEL" && len(keys) == 1:
+		_, ok := n.data[keys[0]]
+		delete(n.data, keys[0])
+		if ok {
+			w.WriteString(":1\r\n")
+		} else {
+			w.WriteString(":0\r\n")
+		}
+	case cmd == "PING":
+		w.WriteString("+PONG\r\n")
+	default:
+		fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("unexpected command line %q", line)
+	}
+	args := make([]string, count)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestRedisCache_Cluster(t *testing.T) {
+	node := newFakeClusterNode(t)
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{node.ln.Addr().String()}, MaxRedirects: -1})
+	t.Cleanup(func() { client.Close() })
+	c := NewRedisCache(client)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "user:id:1", "kim@example.com", 0))
+	require.NoError(t, c.Set(ctx, "user:email:kim@example.com", "{}", 0))
+
+	values, err := c.MGet(ctx, "user:id:1", "user:email:kim@example.com", "user:id:2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user:id:1": "kim@example.com", "user:email:kim@example.com": "{}"}, values)
+
+	require.NoError(t, c.Delete(ctx, "user:id:1", "user:email:kim@example.com"))
+	values, err = c.MGet(ctx, "user:id:1", "user:email:kim@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	// 같은 명령을 그대로 보내면 클러스터가 거절한다
+	assert.ErrorContains(t, client.Del(ctx, "a", "b").Err(), "CROSSSLOT")
+}
@@ -2,28 +2,88 @@ package cache
 
 import (
 	"context"
-	"log"
-	"os"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/go-redis/redis/v8"
+	"module.resume/internal/config"
 )
 
-func NewRedisClient() (*redis.Client, error) {
-	redisAddr := os.Getenv("REDIS_ADDR")
-	if redisAddr == "" {
-		log.Fatal("REDIS_ADDR 없음")
+func NewRedisClient(cfg config.RedisConfig, log *slog.Logger) (redis.UniversalClient, error) {
+	opts, err := redisOptions(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	opts := &redis.Options{
-		Addr: redisAddr,
+	var rdb redis.UniversalClient
+	if cfg.Cluster {
+		rdb = redis.NewClusterClient(opts.Cluster())
+	} else {
+		rdb = redis.NewUniversalClient(opts)
 	}
-	rdb := redis.NewClient(opts)
 
+	// connection 재시도하기
 	ctx := context.Background()
-	if _, err := rdb.Ping(ctx).Result(); err != nil {
-		return nil, err
+	for i := 0; i < 5; i++ {
+		err = rdb.Ping(ctx).Err()
+		if err == nil {
+			break
+		}
+		log.Warn("Failed to connect to redis. Retrying in 2 seconds...", "attempt", i+1, "max_attempts", 5)
+		time.Sleep(2 * time.Second)
 	}
 
-	log.Println("Redis connection established")
+	if err != nil {
+		_ = rdb.Close()
+		return nil, fmt.Errorf("failed to connect to redis after multiple retries: %w", err)
+	}
+
+	log.Info("Redis connection established")
 	return rdb, nil
 }
+
+func redisOptions(cfg config.RedisConfig) (*redis.UniversalOptions, error) {
+	opts := &redis.UniversalOptions{}
+
+	if cfg.URL != "" {
+		parsed, err := redis.ParseURL(cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+		}
+		opts.Addrs = []string{parsed.Addr}
+		opts.Username = parsed.Username
+		opts.Password = parsed.Password
+		opts.DB = parsed.DB
+		opts.TLSConfig = parsed.TLSConfig
+	}
+
+	if len(cfg.Addrs) > 0 {
+		opts.Addrs = nil
+		for _, addr := range cfg.Addrs {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				opts.Addrs = append(opts.Addrs, addr)
+			}
+		}
+	}
+
+	if len(opts.Addrs) == 0 {
+		return nil, errors.New("REDIS_URL or REDIS_ADDR environment variable not set")
+	}
+
+	if cfg.SentinelMaster != "" {
+		if cfg.Cluster {
+			return nil, errors.New("redis sentinel and cluster mode cannot be used together")
+		}
+		opts.MasterName = cfg.SentinelMaster
+		opts.SentinelPassword = cfg.SentinelPassword
+	}
+
+	if cfg.Cluster && opts.DB != 0 {
+		return nil, errors.New("redis cluster mode only supports db 0")
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingHook(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	hook := NewTracingHook(tp)
+	ctx := context.Background()
+
+	t.Run("miss is not an error", func(t *testing.T) {
+		exporter.Reset()
+		cmd := redis.NewStringCmd(ctx, "get", "blocklist:secret-token")
+		cmd.SetErr(redis.Nil)
+
+		spanCtx, err := hook.BeforeProcess(ctx, cmd)
+		require.NoError(t, err)
+		require.NoError(t, hook.AfterProcess(spanCtx, cmd))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "redis.get", spans[0].Name)
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+		for _, attr := range spans[0].Attributes {
+			assert.NotContains(t, attr.Value.Emit(), "secret-token")
+		}
+	})
+
+	t.Run("pipeline error", func(t *testing.T) {
+		exporter.Reset()
+		set := redis.NewStatusCmd(ctx, "set", "a", "1")
+		get := redis.NewStringCmd(ctx, "get", "a")
+		get.SetErr(errors.New("connection reset"))
+		cmds := []redis.Cmder{set, get}
+
+		spanCtx, err := hook.BeforeProcessPipeline(ctx, cmds)
+		require.NoError(t, err)
+		require.NoError(t, hook.AfterProcessPipeline(spanCtx, cmds))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "redis.pipeline", spans[0].Name)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+	})
+}
@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"module.resume/internal/application"
+	"module.resume/internal/domain/user"
+)
+
+// cachedUser 는 캐시에 넣는 사용자 정보. 여러 노드가 함께 보는 캐시라서 비밀번호 해시는 넣지 않는다
+// 비밀번호 확인은 트랜잭션 안의 조회나 FindCredentials 로 저장소에서 읽는다
+type cachedUser struct {
+	ID         uint       `json:"id"`
+	Email      string     `json:"email"`
+	Name       string     `json:"name"`
+	ProfileUrl string     `json:"profile_url"`
+	AvatarKey  string     `json:"avatar_key,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+
+	TokensInvalidBefore *time.Time `json:"tokens_invalid_before,omitempty"`
+}
+
+func toCachedUser(u *user.User) cachedUser {
+	return cachedUser{
+		ID:         u.ID,
+		Email:      u.Email,
+		Name:       u.Name,
+		ProfileUrl: u.ProfileUrl,
+		AvatarKey:  u.AvatarKey,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+		DeletedAt:  u.DeletedAt,
+
+		TokensInvalidBefore: u.TokensInvalidBefore,
+	}
+}
+
+func (c cachedUser) toDomain() *user.User {
+	return &user.User{
+		ID:         c.ID,
+		Email:      c.Email,
+		Name:       c.Name,
+		ProfileUrl: c.ProfileUrl,
+		AvatarKey:  c.AvatarKey,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+		DeletedAt:  c.DeletedAt,
+
+		TokensInvalidBefore: c.TokensInvalidBefore,
+	}
+}
+
+// CachedUserRepository 는 user.Repository 의 조회 결과를 캐시에 저장하는 데코레이터
+type CachedUserRepository struct {
+	repo user.Repository
+	rt   *readThrough
+}
+
+func NewCachedUserRepository(repo user.Repository, cache application.Cache, ttl time.Duration) *CachedUserRepository {
+	return &CachedUserRepository{
+		repo: repo,
+		rt:   newReadThrough(cache, ttl),
+	}
+}
+
+func userEmailKey(email string) string {
+	return "user:email:" + email
+}
+
+// id 로 들어오는 Update/Delete 에서 email 키를 찾기 위한 인덱스
+func userIDKey(id uint) string {
+	return "user:id:" + strconv.FormatUint(uint64(id), 10)
+}
+
+// FindByID 는 id 인덱스로 email 을 찾을 수 있으면 email 캐시를 쓰고, 아니면 저장소에서 읽는다
+func (r *CachedUserRepository) FindByID(ctx context.Context, id uint) (*user.User, error) {
+	if !application.InTransaction(ctx) {
+		if email, ok := r.rt.lookup(ctx, userIDKey(id)); ok {
+			if found, err := r.FindByEmail(ctx, email); err == nil && found.ID == id {
+				return found, nil
+			}
+		}
+	}
+	return r.repo.FindByID(ctx, id)
+}
+
+func (r *CachedUserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	// 트랜잭션 안에서 읽은 값은 롤백될 수 있으므로 캐시에 넣지 않는다
+	if application.InTransaction(ctx) {
+		return r.repo.FindByEmail(ctx, email)
+	}
+	val, err := r.rt.get(ctx, userEmailKey(email), func(ctx context.Context) (string, error) {
+		found, err := r.repo.FindByEmail(ctx, email)
+		if err != nil {
+			return "", err
+		}
+		b, err := json.Marshal(toCachedUser(found))
+		if err != nil {
+			return "", err
+		}
+		_ = r.rt.cache.Set(ctx, userIDKey(found.ID), found.Email, r.rt.ttl)
+		return string(b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var cached cachedUser
+	if err := json.Unmarshal([]byte(val), &cached); err != nil {
+		r.rt.invalidate(ctx, userEmailKey(email))
+		return r.repo.FindByEmail(ctx, email)
+	}
+	return cached.toDomain(), nil
+}
+
+func (r *CachedUserRepository) Save(ctx context.Context, u *user.User) (uint, error) {
+	id, err := r.repo.Save(ctx, u)
+	if err != nil {
+		return 0, err
+	}
+	r.afterCommit(ctx, func(ctx context.Context) {
+		r.rt.invalidate(ctx, userEmailKey(u.Email), userIDKey(id))
+	})
+	return id, nil
+}
+
+func (r *CachedUserRepository) Update(ctx context.Context, u *user.User) (uint, error) {
+	id, err := r.repo.Update(ctx, u)
+	if err != nil {
+		return 0, err
+	}
+	r.afterCommit(ctx, func(ctx context.Context) {
+		r.invalidateByID(ctx, u.ID, u.Email)
+	})
+	return id, nil
+}
+
+func (r *CachedUserRepository) UpdatePassword(ctx context.Context, u *user.User) error {
+	if err := r.repo.UpdatePassword(ctx, u); err != nil {
+		return err
+	}
+	r.afterCommit(ctx, func(ctx context.Context) {
+		r.invalidateByID(ctx, u.ID, u.Email)
+	})
+	return nil
+}
+
+// UpdateEmail 은 id 인덱스가 이미 밀려났을 수 있어 예전 email 키를 직접 지운다
+func (r *CachedUserRepository) UpdateEmail(ctx context.Context, u *user.User, oldEmail string) error {
+	if err := r.repo.UpdateEmail(ctx, u, oldEmail); err != nil {
+		return err
+	}
+	r.afterCommit(ctx, func(ctx context.Context) {
+		r.invalidateByID(ctx, u.ID, u.Email)
+		r.rt.invalidate(ctx, userEmailKey(oldEmail))
+	})
+	return nil
+}
+
+func (r *CachedUserRepository) UpdateAvatar(ctx context.Context, u *user.User) error {
+	if err := r.repo.UpdateAvatar(ctx, u); err != nil {
+		return err
+	}
+	r.afterCommit(ctx, func(ctx context.Context) {
+		r.invalidateByID(ctx, u.ID, u.Email)
+	})
+	return nil
+}
+
+func (r *CachedUserRepository) Delete(ctx context.Context, u *user.User) error {
+	if err := r.repo.Delete(ctx, u); err != nil {
+		return err
+	}
+	r.afterCommit(ctx, func(ctx context.Context) {
+		r.invalidateByID(ctx, u.ID, u.Email)
+	})
+	return nil
+}
+
+func (r *CachedUserRepository) FindCredentials(ctx context.Context, email string) (*user.User, error) {
+	return r.repo.FindCredentials(ctx, email)
+}
+
+// FindDeletedByEmail 은 탈퇴 취소에만 쓰여서 캐시하지 않는다
+func (r *CachedUserRepository) FindDeletedByEmail(ctx context.Context, email string) (*user.User, error) {
+	return r.repo.FindDeletedByEmail(ctx, email)
+}
+
+func (r *CachedUserRepository) Restore(ctx context.Context, u *user.User) error {
+	if err := r.repo.Restore(ctx, u); err != nil {
+		return err
+	}
+	r.afterCommit(ctx, func(ctx context.Context) {
+		r.invalidateByID(ctx, u.ID, u.Email)
+	})
+	return nil
+}
+
+func (r *CachedUserRepository) Stats() Stats {
+	return r.rt.stats()
+}
+
+// 커밋 전에 지우면 다른 요청이 아직 바뀌기 전의 값을 다시 캐시에 넣을 수 있어서 커밋 뒤에 지운다
+func (r *CachedUserRepository) afterCommit(ctx context.Context, invalidate func(ctx context.Context)) {
+	application.AfterCommit(ctx, func() {
+		invalidate(context.WithoutCancel(ctx))
+	})
+}
+
+func (r *CachedUserRepository) invalidateByID(ctx context.Context, id uint, email string) {
+	keys := []string{userIDKey(id)}
+	if email != "" {
+		keys = append(keys, userEmailKey(email))
+	}
+	// email 이 바뀐 경우 예전 email 키도 지워야 한다
+	if oldEmail, ok := r.rt.lookup(ctx, userIDKey(id)); ok && oldEmail != email {
+		keys = append(keys, userEmailKey(oldEmail))
+	}
+	r.rt.invalidate(ctx, keys...)
+}
@@ -0,0 +1,333 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/application"
+	"module.resume/internal/domain/user"
+)
+
+type fakeUserRepository struct {
+	mu      sync.Mutex
+	users   map[string]*user.User
+	deleted map[string]*user.User
+	finds   atomic.Int32
+	release chan struct{}
+}
+
+func newFakeUserRepository(users ...*user.User) *fakeUserRepository {
+	repo := &fakeUserRepository{users: make(map[string]*user.User), deleted: make(map[string]*user.User)}
+	for _, u := range users {
+		repo.users[u.Email] = u
+	}
+	return repo
+}
+
+func (f *fakeUserRepository) FindByID(ctx context.Context, id uint) (*user.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.users {
+		if u.ID == id {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (f *fakeUserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	f.finds.Add(1)
+	if f.release != nil {
+		<-f.release
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[email]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (f *fakeUserRepository) FindCredentials(ctx context.Context, email string) (*user.User, error) {
+	return f.FindByEmail(ctx, email)
+}
+
+func (f *fakeUserRepository) Save(ctx context.Context, u *user.User) (uint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u.ID = uint(len(f.users) + 1)
+	f.users[u.Email] = u
+	return u.ID, nil
+}
+
+func (f *fakeUserRepository) Update(ctx context.Context, u *user.User) (uint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, stored := range f.users {
+		if stored.ID == u.ID {
+			stored.Name = u.Name
+		}
+	}
+	return u.ID, nil
+}
+
+func (f *fakeUserRepository) UpdateEmail(ctx context.Context, u *user.User, oldEmail string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for email, stored := range f.users {
+		if stored.ID == u.ID {
+			delete(f.users, email)
+			stored.Email = u.Email
+			f.users[u.Email] = stored
+		}
+	}
+	return nil
+}
+
+func (f *fakeUserRepository) UpdatePassword(ctx context.Context, u *user.User) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, stored := range f.users {
+		if stored.ID == u.ID {
+			stored.SetPasswordHash(u.PasswordHash())
+			stored.TokensInvalidBefore = u.TokensInvalidBefore
+		}
+	}
+	return nil
+}
+
+func (f *fakeUserRepository) UpdateAvatar(ctx context.Context, u *user.User) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, stored := range f.users {
+		if stored.ID == u.ID {
+			stored.AvatarKey = u.AvatarKey
+		}
+	}
+	return nil
+}
+
+func (f *fakeUserRepository) Delete(ctx context.Context, u *user.User) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for email, stored := range f.users {
+		if stored.ID == u.ID {
+			delete(f.users, email)
+			f.deleted[email] = stored
+		}
+	}
+	return nil
+}
+
+func (f *fakeUserRepository) FindDeletedByEmail(ctx context.Context, email string) (*user.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.deleted[email]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (f *fakeUserRepository) Restore(ctx context.Context, u *user.User) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for email, stored := range f.deleted {
+		if stored.ID == u.ID {
+			delete(f.deleted, email)
+			f.users[email] = stored
+		}
+	}
+	return nil
+}
+
+func TestCachedUserRepository_FindByEmail(t *testing.T) {
+	stored := &user.User{ID: 1, Email: "test@example.com", Name: "Test"}
+	stored.SetPasswordHash("hash")
+	inner := newFakeUserRepository(stored)
+	repo := NewCachedUserRepository(inner, NewMemoryCache(100), time.Minute)
+	ctx := context.Background()
+
+	first, err := repo.FindByEmail(ctx, stored.Email)
+	assert.NoError(t, err)
+	second, err := repo.FindByEmail(ctx, stored.Email)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), inner.finds.Load())
+	assert.Equal(t, first, second)
+	assert.Equal(t, Stats{Hits: 1, Misses: 1}, repo.Stats())
+}
+
+func TestCachedUserRepository_PasswordHashNotCached(t *testing.T) {
+	stored := &user.User{ID: 1, Email: "test@example.com"}
+	stored.SetPasswordHash("hash")
+	inner := newFakeUserRepository(stored)
+	shared := NewMemoryCache(100)
+	repo := NewCachedUserRepository(inner, shared, time.Minute)
+	ctx := context.Background()
+
+	_, err := repo.FindByEmail(ctx, stored.Email)
+	require.NoError(t, err)
+	raw, err := shared.Get(ctx, userEmailKey(stored.Email))
+	require.NoError(t, err)
+	assert.NotContains(t, raw, "hash")
+
+	cached, err := repo.FindByEmail(ctx, stored.Email)
+	require.NoError(t, err)
+	assert.Empty(t, cached.PasswordHash())
+
+	// 비밀번호 확인용 조회는 캐시를 거치지 않고 해시를 읽는다
+	creds, err := repo.FindCredentials(ctx, stored.Email)
+	require.NoError(t, err)
+	assert.Equal(t, "hash", creds.PasswordHash())
+}
+
+func TestCachedUserRepository_NotFoundIsNotCached(t *testing.T) {
+	inner := newFakeUserRepository()
+	repo := NewCachedUserRepository(inner, NewMemoryCache(100), time.Minute)
+	ctx := context.Background()
+
+	_, err := repo.FindByEmail(ctx, "nobody@example.com")
+	assert.Error(t, err)
+	_, err = repo.FindByEmail(ctx, "nobody@example.com")
+	assert.Error(t, err)
+
+	assert.Equal(t, int32(2), inner.finds.Load())
+}
+
+func TestCachedUserRepository_FindByID(t *testing.T) {
+	stored := &user.User{ID: 1, Email: "id@example.com", Name: "Test"}
+	inner := newFakeUserRepository(stored)
+	repo := NewCachedUserRepository(inner, NewMemoryCache(100), time.Minute)
+	ctx := context.Background()
+
+	// email 로 한 번 읽기 전에는 id 인덱스가 없어서 저장소에서 읽는다
+	found, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Test", found.Name)
+	assert.Equal(t, int32(0), inner.finds.Load())
+
+	_, err = repo.FindByEmail(ctx, stored.Email)
+	require.NoError(t, err)
+	found, err = repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), found.ID)
+	assert.Equal(t, int32(1), inner.finds.Load())
+}
+
+func TestCachedUserRepository_Invalidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("update", func(t *testing.T) {
+		inner := newFakeUserRepository(&user.User{ID: 1, Email: "old@example.com", Name: "Old"})
+		repo := NewCachedUserRepository(inner, NewMemoryCache(100), time.Minute)
+		_, err := repo.FindByEmail(ctx, "old@example.com")
+		assert.NoError(t, err)
+
+		_, err = repo.Update(ctx, &user.User{ID: 1, Email: "old@example.com", Name: "New"})
+		assert.NoError(t, err)
+
+		found, err := repo.FindByEmail(ctx, "old@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "New", found.Name)
+	})
+
+	t.Run("update email", func(t *testing.T) {
+		inner := newFakeUserRepository(&user.User{ID: 1, Email: "old@example.com", Name: "Old"})
+		repo := NewCachedUserRepository(inner, NewMemoryCache(100), time.Minute)
+		_, err := repo.FindByEmail(ctx, "old@example.com")
+		assert.NoError(t, err)
+
+		// id 인덱스가 밀려나도 예전 email 키는 지워져야 한다
+		repo.rt.invalidate(ctx, userIDKey(1))
+		assert.NoError(t, repo.UpdateEmail(ctx, &user.User{ID: 1, Email: "new@example.com"}, "old@example.com"))
+
+		_, err = repo.FindByEmail(ctx, "old@example.com")
+		assert.Error(t, err)
+		found, err := repo.FindByEmail(ctx, "new@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "Old", found.Name)
+	})
+
+	t.Run("delete and restore", func(t *testing.T) {
+		stored := &user.User{ID: 1, Email: "gone@example.com"}
+		inner := newFakeUserRepository(stored)
+		repo := NewCachedUserRepository(inner, NewMemoryCache(100), time.Minute)
+		_, err := repo.FindByEmail(ctx, "gone@example.com")
+		assert.NoError(t, err)
+
+		assert.NoError(t, repo.Delete(ctx, stored))
+
+		_, err = repo.FindByEmail(ctx, "gone@example.com")
+		assert.Error(t, err)
+
+		assert.NoError(t, repo.Restore(ctx, stored))
+
+		_, err = repo.FindByEmail(ctx, "gone@example.com")
+		assert.NoError(t, err)
+	})
+}
+
+func TestCachedUserRepository_Transaction(t *testing.T) {
+	stored := &user.User{ID: 1, Email: "tx@example.com", Name: "old"}
+	inner := newFakeUserRepository(stored)
+	repo := NewCachedUserRepository(inner, NewMemoryCache(100), time.Minute)
+	ctx := context.Background()
+	_, err := repo.FindByEmail(ctx, "tx@example.com")
+	require.NoError(t, err)
+
+	txCtx, commit := application.WithAfterCommitHooks(ctx)
+	_, err = repo.Update(txCtx, &user.User{ID: 1, Email: "tx@example.com", Name: "new"})
+	require.NoError(t, err)
+
+	// 트랜잭션 안의 조회는 캐시를 거치지 않는다
+	inTx, err := repo.FindByEmail(txCtx, "tx@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "new", inTx.Name)
+
+	// 커밋 전에는 캐시가 커밋된 값을 그대로 돌려준다
+	before, err := repo.FindByEmail(ctx, "tx@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "old", before.Name)
+
+	commit()
+	after, err := repo.FindByEmail(ctx, "tx@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "new", after.Name)
+}
+
+func TestCachedUserRepository_SingleFlight(t *testing.T) {
+	inner := newFakeUserRepository(&user.User{ID: 1, Email: "hot@example.com"})
+	inner.release = make(chan struct{})
+	repo := NewCachedUserRepository(inner, NewMemoryCache(100), time.Minute)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			found, err := repo.FindByEmail(ctx, "hot@example.com")
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), found.ID)
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return repo.Stats().Misses == 10 }, time.Second, time.Millisecond)
+	// miss 를 센 뒤 singleflight 에 들어갈 시간을 준다
+	time.Sleep(20 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), inner.finds.Load())
+}
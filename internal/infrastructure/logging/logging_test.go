@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"module.resume/internal/config"
+)
+
+func TestLogger_RedactsSensitiveKeys(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(&buf, config.LogConfig{Level: "info"})
+
+	logger.Info("login",
+		slog.String("email", "user@example.com"),
+		slog.String("password", "hunter2hunter2"),
+		slog.String("accessToken", "eyJhbGciOi"),
+		slog.String("Authorization", "Bearer eyJhbGciOi"),
+	)
+
+	var line map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "user@example.com", line["email"])
+	assert.Equal(t, redacted, line["password"])
+	assert.Equal(t, redacted, line["accessToken"])
+	assert.Equal(t, redacted, line["Authorization"])
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "eyJhbGciOi")
+}
+
+func TestLogger_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(&buf, config.LogConfig{Level: "warn"})
+
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	assert.NotContains(t, buf.String(), "dropped")
+	assert.Contains(t, buf.String(), "kept")
+}
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(&buf, config.LogConfig{Level: "info"})
+
+	ctx := WithLogger(context.Background(), logger)
+	ctx = With(ctx, "request_id", "abc-123")
+	ctx = WithRequestID(ctx, "abc-123")
+	FromContext(ctx).Info("hello")
+
+	assert.Equal(t, "abc-123", RequestID(ctx))
+	assert.Contains(t, buf.String(), `"request_id":"abc-123"`)
+}
@@ -0,0 +1,25 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+
+	"module.resume/internal/application"
+)
+
+// LogMailer 는 메일을 보내지 않고 로그로만 남긴다. 메일 서버가 없는 개발 환경용
+// 본문에는 확인 토큰이나 서명된 링크가 들어 있어서 받는 사람과 제목만 남긴다
+type LogMailer struct {
+	log *slog.Logger
+}
+
+func NewLogMailer(log *slog.Logger) *LogMailer {
+	return &LogMailer{log: log}
+}
+
+var _ application.Mailer = (*LogMailer)(nil)
+
+func (m *LogMailer) Send(ctx context.Context, mail application.Mail) error {
+	m.log.InfoContext(ctx, "Mail not delivered, log mail backend", "to", mail.To, "subject", mail.Subject)
+	return nil
+}
@@ -0,0 +1,104 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/application"
+)
+
+type receivedMail struct {
+	from, to string
+	data     string
+}
+
+// fakeSMTPServer 는 한 번의 접속을 받아 메일 한 통을 받는 최소한의 SMTP 서버
+func fakeSMTPServer(t *testing.T) (string, <-chan receivedMail) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan receivedMail, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		var mail receivedMail
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "MAIL":
+				mail.from = line
+				tp.PrintfLine("250 OK")
+			case "RCPT":
+				mail.to = line
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				mail.data = string(data)
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				received <- mail
+				return
+			default:
+				tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	addr, received := fakeSMTPServer(t)
+	mailer, err := NewSMTPMailer(addr, "", "", "no-reply@example.com")
+	require.NoError(t, err)
+
+	err = mailer.Send(context.Background(), application.Mail{
+		To:      "kim@example.com",
+		Subject: "데이터 내보내기 완료",
+		Body:    "다운로드: https://example.com/user/export/1/download?sig=abc\n",
+	})
+	require.NoError(t, err)
+
+	mail := <-received
+	assert.Equal(t, "MAIL FROM:<no-reply@example.com>", mail.from)
+	assert.Equal(t, "RCPT TO:<kim@example.com>", mail.to)
+	header, body, ok := strings.Cut(mail.data, "\n\n")
+	require.True(t, ok, mail.data)
+	assert.Contains(t, header, "To: kim@example.com")
+	assert.Contains(t, header, "Subject: =?utf-8?q?")
+	decoded, err := io.ReadAll(quotedprintable.NewReader(bufio.NewReader(strings.NewReader(body))))
+	require.NoError(t, err)
+	assert.Contains(t, string(decoded), "sig=abc")
+}
+
+func TestSMTPMailer_RejectsHeaderInjection(t *testing.T) {
+	mailer, err := NewSMTPMailer("127.0.0.1:1", "", "", "no-reply@example.com")
+	require.NoError(t, err)
+
+	err = mailer.Send(context.Background(), application.Mail{To: "kim@example.com\r\nBcc: evil@example.com", Subject: "hi"})
+
+	assert.ErrorContains(t, err, "line break")
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"module.resume/internal/application"
+	"module.resume/internal/auth"
+	"module.resume/internal/domain/user"
+)
+
+// instrumentedAuthService 는 authService 를 건드리지 않고 인증 결과를 센다
+type instrumentedAuthService struct {
+	application.AuthService
+	metrics *Metrics
+}
+
+func InstrumentAuthService(service application.AuthService, metrics *Metrics) application.AuthService {
+	return &instrumentedAuthService{
+		AuthService: service,
+		metrics:     metrics,
+	}
+}
+
+func (s *instrumentedAuthService) Login(ctx context.Context, user *user.User) (string, error) {
+	token, err := s.AuthService.Login(ctx, user)
+	if err != nil {
+		s.metrics.loginFailures.Inc()
+		return "", err
+	}
+	s.metrics.logins.Inc()
+	return token, nil
+}
+
+func (s *instrumentedAuthService) Authenticate(ctx context.Context, token string) (*auth.Claims, error) {
+	claims, err := s.AuthService.Authenticate(ctx, token)
+	if errors.Is(err, application.ErrTokenBlocklisted) {
+		s.metrics.blocklistRejections.Inc()
+	}
+	return claims, err
+}
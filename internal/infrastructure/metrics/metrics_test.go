@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"module.resume/internal/api/middleware"
+	"module.resume/internal/application"
+	"module.resume/internal/auth"
+	"module.resume/internal/domain/user"
+)
+
+func TestMetrics_HTTPRequestsUseRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := New()
+	r := gin.New()
+	r.Use(middleware.MetricsMiddleware(m))
+	r.GET("/user/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for _, path := range []string{"/user/1", "/user/2", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/user/:id", "204")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "unmatched", "404")))
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New()
+	m.RegisterCacheStats("user", func() (uint64, uint64) { return 3, 1 })
+	m.ObserveHTTPRequest("GET", "/healthz", http.StatusOK, 0)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := w.Body.String()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, body, `resume_cache_hits_total{cache="user"} 3`)
+	assert.Contains(t, body, `resume_cache_misses_total{cache="user"} 1`)
+	assert.Contains(t, body, `resume_http_requests_total{method="GET",route="/healthz",status="200"} 1`)
+}
+
+type stubAuthService struct {
+	loginErr        error
+	authenticateErr error
+}
+
+func (s *stubAuthService) Login(ctx context.Context, user *user.User) (string, error) {
+	if s.loginErr != nil {
+		return "", s.loginErr
+	}
+	return "token", nil
+}
+
+func (s *stubAuthService) Logout(ctx context.Context, token string) error {
+	return nil
+}
+
+func (s *stubAuthService) Authenticate(ctx context.Context, token string) (*auth.Claims, error) {
+	if s.authenticateErr != nil {
+		return nil, s.authenticateErr
+	}
+	return &auth.Claims{}, nil
+}
+
+func TestInstrumentAuthService(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+	stub := &stubAuthService{}
+	service := InstrumentAuthService(stub, m)
+
+	_, _ = service.Login(ctx, &user.User{})
+	stub.loginErr = errors.New("invalid password")
+	_, _ = service.Login(ctx, &user.User{})
+	_, _ = service.Login(ctx, &user.User{})
+
+	stub.authenticateErr = application.ErrTokenBlocklisted
+	_, _ = service.Authenticate(ctx, "token")
+	stub.authenticateErr = errors.New("token is expired")
+	_, _ = service.Authenticate(ctx, "token")
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.logins))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.loginFailures))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.blocklistRejections))
+}
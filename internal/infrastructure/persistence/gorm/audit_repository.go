@@ -0,0 +1,176 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+	"module.resume/internal/domain/audit"
+)
+
+const auditNotFound = "audit entry not found"
+
+type AuditLog struct {
+	ID         int64           `gorm:"column:id;primaryKey"`
+	OccurredAt time.Time       `gorm:"column:occurred_at"`
+	Action     string          `gorm:"column:action"`
+	ActorID    *uint           `gorm:"column:actor_id"`
+	SubjectID  *uint           `gorm:"column:subject_id"`
+	IP         *string         `gorm:"column:ip"`
+	UserAgent  *string         `gorm:"column:user_agent"`
+	RequestID  *string         `gorm:"column:request_id"`
+	Metadata   json.RawMessage `gorm:"column:metadata"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
+
+func (m AuditLog) toDomain() audit.Entry {
+	e := audit.Entry{
+		ID:         m.ID,
+		OccurredAt: m.OccurredAt,
+		Action:     m.Action,
+		ActorID:    deref(m.ActorID),
+		SubjectID:  deref(m.SubjectID),
+		IP:         deref(m.IP),
+		UserAgent:  deref(m.UserAgent),
+		RequestID:  deref(m.RequestID),
+	}
+	_ = json.Unmarshal(m.Metadata, &e.Metadata)
+	return e
+}
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+var (
+	_ audit.Repository             = (*AuditRepository)(nil)
+	_ application.UserDataEraser   = (*AuditRepository)(nil)
+	_ application.UserDataExporter = (*AuditRepository)(nil)
+)
+
+func (r *AuditRepository) Append(ctx context.Context, e *audit.Entry) error {
+	metadata, err := json.Marshal(e.Metadata)
+	if err != nil {
+		return err
+	}
+	if e.Metadata == nil {
+		metadata = json.RawMessage("{}")
+	}
+	m := &AuditLog{
+		OccurredAt: e.OccurredAt,
+		Action:     e.Action,
+		ActorID:    nullable(e.ActorID),
+		SubjectID:  nullable(e.SubjectID),
+		IP:         nullable(e.IP),
+		UserAgent:  nullable(e.UserAgent),
+		RequestID:  nullable(e.RequestID),
+		Metadata:   metadata,
+	}
+	if err := Conn(ctx, r.db).Create(m).Error; err != nil {
+		return translateError(err, auditNotFound)
+	}
+	e.ID = m.ID
+	return nil
+}
+
+func (r *AuditRepository) Search(ctx context.Context, f audit.Filter) ([]audit.Entry, error) {
+	var rows []AuditLog
+	err := withRetry(ctx, func() error {
+		q := Conn(ctx, r.db).Order("id DESC").Limit(f.Limit)
+		if f.ActorID != 0 {
+			q = q.Where("actor_id = ?", f.ActorID)
+		}
+		if f.SubjectID != 0 {
+			q = q.Where("subject_id = ?", f.SubjectID)
+		}
+		if f.Action != "" {
+			q = q.Where("action = ?", f.Action)
+		}
+		if f.IP != "" {
+			q = q.Where("ip = ?", f.IP)
+		}
+		if !f.Since.IsZero() {
+			q = q.Where("occurred_at >= ?", f.Since)
+		}
+		if !f.Until.IsZero() {
+			q = q.Where("occurred_at < ?", f.Until)
+		}
+		return q.Find(&rows).Error
+	})
+	if err != nil {
+		return nil, translateError(err, auditNotFound)
+	}
+	entries := make([]audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, row.toDomain())
+	}
+	return entries, nil
+}
+
+// EraseUserData 는 기록은 남기고 IP, user agent, metadata 의 email 주소만 지운다
+// audit_log 는 트리거로 UPDATE 가 막혀 있어서 이 트랜잭션에서만 audit.erasing 을 켠다
+func (r *AuditRepository) EraseUserData(ctx context.Context, userID uint) error {
+	conn := Conn(ctx, r.db)
+	if err := conn.Exec(`SELECT set_config('audit.erasing', 'on', true)`).Error; err != nil {
+		return err
+	}
+	return conn.Exec(
+		`UPDATE audit_log SET ip = NULL, user_agent = NULL, metadata = metadata - ARRAY['email', 'old_email', 'new_email']
+		 WHERE (actor_id = ? OR subject_id = ?) AND (ip IS NOT NULL OR user_agent IS NOT NULL OR metadata <> '{}')`,
+		userID, userID,
+	).Error
+}
+
+type exportedAuditEntry struct {
+	Action     string            `json:"action"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	IP         string            `json:"ip,omitempty"`
+	UserAgent  string            `json:"user_agent,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// ExportUserData 는 사용자 계정에 대한 보안 기록을 내보낸다
+func (r *AuditRepository) ExportUserData(ctx context.Context, userID uint) (string, interface{}, error) {
+	var rows []AuditLog
+	if err := Conn(ctx, r.db).Where("subject_id = ?", userID).Order("id").Find(&rows).Error; err != nil {
+		return "", nil, err
+	}
+	entries := make([]exportedAuditEntry, 0, len(rows))
+	for _, row := range rows {
+		e := row.toDomain()
+		entries = append(entries, exportedAuditEntry{
+			Action:     e.Action,
+			OccurredAt: e.OccurredAt,
+			IP:         e.IP,
+			UserAgent:  e.UserAgent,
+			Metadata:   e.Metadata,
+		})
+	}
+	return "security_events.json", entries, nil
+}
+
+// nullable 은 빈 값을 NULL 로 저장하기 위한 포인터를 돌려준다
+func nullable[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -2,42 +2,28 @@ package gorm
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"module.resume/internal/config"
 )
 
-func NewDB() (*gorm.DB, error) {
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		log.Fatal("DATABASE_URL environment variable not set")
-	}
-
-	newLogger := logger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags),
-		logger.Config{
-			SlowThreshold:             time.Second,
-			LogLevel:                  logger.Info,
-			IgnoreRecordNotFoundError: true,
-			Colorful:                  true,
-		},
-	)
+func NewDB(cfg config.DatabaseConfig, logCfg config.LogConfig, log *slog.Logger) (*gorm.DB, error) {
+	newLogger := NewLogger(logCfg.SQLLevel, logCfg.SlowSQLThreshold)
 
 	// connection 재시도하기
 	var db *gorm.DB
 	var err error
 	for i := 0; i < 5; i++ {
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
+		db, err = gorm.Open(postgres.Open(cfg.URL), &gorm.Config{
 			Logger: newLogger,
 		})
 		if err == nil {
 			break
 		}
-		log.Printf("Failed to connect to database (attempt %d/5). Retrying in 2 seconds...", i+1)
+		log.Warn("Failed to connect to database. Retrying in 2 seconds...", "attempt", i+1, "max_attempts", 5)
 		time.Sleep(2 * time.Second)
 	}
 
@@ -50,10 +36,10 @@ func NewDB() (*gorm.DB, error) {
 		return nil, err
 	}
 
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(30)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
-	log.Println("Database connection established")
+	log.Info("Database connection established")
 	return db, nil
 }
@@ -0,0 +1,158 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/user"
+)
+
+const emailChangeNotFound = "link is invalid or has expired"
+
+type EmailChange struct {
+	ID               int64      `gorm:"column:id;primaryKey"`
+	UserID           uint       `gorm:"column:user_id"`
+	OldEmail         string     `gorm:"column:old_email"`
+	NewEmail         string     `gorm:"column:new_email"`
+	ConfirmTokenHash string     `gorm:"column:confirm_token_hash"`
+	UndoTokenHash    string     `gorm:"column:undo_token_hash"`
+	Status           string     `gorm:"column:status"`
+	CreatedAt        time.Time  `gorm:"column:created_at"`
+	ExpiresAt        time.Time  `gorm:"column:expires_at"`
+	UndoExpiresAt    time.Time  `gorm:"column:undo_expires_at"`
+	CompletedAt      *time.Time `gorm:"column:completed_at"`
+}
+
+func (EmailChange) TableName() string {
+	return "email_change"
+}
+
+func (m EmailChange) toDomain() *user.EmailChange {
+	return &user.EmailChange{
+		ID:               m.ID,
+		UserID:           m.UserID,
+		OldEmail:         m.OldEmail,
+		NewEmail:         m.NewEmail,
+		ConfirmTokenHash: m.ConfirmTokenHash,
+		UndoTokenHash:    m.UndoTokenHash,
+		Status:           m.Status,
+		CreatedAt:        m.CreatedAt,
+		ExpiresAt:        m.ExpiresAt,
+		UndoExpiresAt:    m.UndoExpiresAt,
+		CompletedAt:      m.CompletedAt,
+	}
+}
+
+type EmailChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailChangeRepository(db *gorm.DB) *EmailChangeRepository {
+	return &EmailChangeRepository{db: db}
+}
+
+var (
+	_ user.EmailChangeRepository   = (*EmailChangeRepository)(nil)
+	_ application.UserDataEraser   = (*EmailChangeRepository)(nil)
+	_ application.UserDataExporter = (*EmailChangeRepository)(nil)
+)
+
+func (r *EmailChangeRepository) Create(ctx context.Context, c *user.EmailChange) error {
+	m := &EmailChange{
+		UserID:           c.UserID,
+		OldEmail:         c.OldEmail,
+		NewEmail:         c.NewEmail,
+		ConfirmTokenHash: c.ConfirmTokenHash,
+		UndoTokenHash:    c.UndoTokenHash,
+		Status:           c.Status,
+		CreatedAt:        c.CreatedAt,
+		ExpiresAt:        c.ExpiresAt,
+		UndoExpiresAt:    c.UndoExpiresAt,
+	}
+	if err := Conn(ctx, r.db).Create(m).Error; err != nil {
+		return translateError(err, emailChangeNotFound)
+	}
+	c.ID = m.ID
+	return nil
+}
+
+func (r *EmailChangeRepository) FindByConfirmToken(ctx context.Context, tokenHash string) (*user.EmailChange, error) {
+	return r.findBy(ctx, "confirm_token_hash = ?", tokenHash)
+}
+
+func (r *EmailChangeRepository) FindByUndoToken(ctx context.Context, tokenHash string) (*user.EmailChange, error) {
+	return r.findBy(ctx, "undo_token_hash = ?", tokenHash)
+}
+
+func (r *EmailChangeRepository) findBy(ctx context.Context, query string, args ...interface{}) (*user.EmailChange, error) {
+	m := &EmailChange{}
+	err := withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Where(query, args...).First(m).Error
+	})
+	if err != nil {
+		return nil, translateError(err, emailChangeNotFound)
+	}
+	return m.toDomain(), nil
+}
+
+func (r *EmailChangeRepository) CancelPending(ctx context.Context, userID uint) error {
+	return translateError(withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Model(&EmailChange{}).
+			Where("user_id = ? AND status = ?", userID, user.EmailChangePending).
+			Updates(map[string]interface{}{"status": user.EmailChangeCancelled, "completed_at": time.Now()}).Error
+	}), emailChangeNotFound)
+}
+
+// Transition 은 같은 링크를 동시에 두 번 눌러도 한 번만 처리되도록 이전 상태를 조건으로 건다
+func (r *EmailChangeRepository) Transition(ctx context.Context, c *user.EmailChange, from string) error {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Model(&EmailChange{}).
+			Where("id = ? AND status = ?", c.ID, from).
+			Updates(map[string]interface{}{"status": c.Status, "completed_at": c.CompletedAt})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return translateError(err, emailChangeNotFound)
+	}
+	if rows == 0 {
+		return domain.NotFound(emailChangeNotFound)
+	}
+	return nil
+}
+
+func (r *EmailChangeRepository) EraseUserData(ctx context.Context, userID uint) error {
+	return Conn(ctx, r.db).Where("user_id = ?", userID).Delete(&EmailChange{}).Error
+}
+
+type exportedEmailChange struct {
+	OldEmail    string     `json:"old_email"`
+	NewEmail    string     `json:"new_email"`
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ExportUserData 는 email 변경 이력을 내보낸다. 토큰 해시는 넣지 않는다
+func (r *EmailChangeRepository) ExportUserData(ctx context.Context, userID uint) (string, interface{}, error) {
+	var changes []EmailChange
+	err := Conn(ctx, r.db).Where("user_id = ?", userID).Order("id").Find(&changes).Error
+	if err != nil {
+		return "", nil, err
+	}
+	exported := make([]exportedEmailChange, 0, len(changes))
+	for _, c := range changes {
+		exported = append(exported, exportedEmailChange{
+			OldEmail:    c.OldEmail,
+			NewEmail:    c.NewEmail,
+			Status:      c.Status,
+			RequestedAt: c.CreatedAt,
+			CompletedAt: c.CompletedAt,
+		})
+	}
+	return "email_changes.json", exported, nil
+}
@@ -0,0 +1,80 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"module.resume/internal/domain"
+)
+
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+const maxRetries = 3
+
+// unique 제약 이름별로 사용자에게 보여줄 메시지
+var conflictMessages = map[string]string{
+	"idx_user_email_active": "email is already registered",
+}
+
+// translateError 는 Postgres/GORM 에러를 domain 에러로 바꾼다
+// notFound 는 레코드가 없을 때 돌려줄 메시지
+func translateError(err error, notFound string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.NotFound(notFound)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		if msg, ok := conflictMessages[pgErr.ConstraintName]; ok {
+			return domain.Conflict(msg)
+		}
+		return domain.Conflict("resource already exists")
+	case pgSerializationFailure, pgDeadlockDetected:
+		return domain.Conflict("concurrent update, please retry")
+	}
+	return err
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
+
+// withRetry 는 직렬화 실패와 데드락일 때 fn 을 다시 실행한다
+// fn 은 처음부터 다시 실행해도 안전한 단위(단일 문장 또는 트랜잭션 전체)여야 한다
+// 트랜잭션 안에서는 실패한 트랜잭션이 이미 중단된 상태라 재시도하지 않고 바깥 TxManager 에 맡긴다
+func withRetry(ctx context.Context, fn func() error) error {
+	if inTransaction(ctx) {
+		return fn()
+	}
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if err = fn(); !isRetryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt+1) * 20 * time.Millisecond):
+		}
+	}
+	return err
+}
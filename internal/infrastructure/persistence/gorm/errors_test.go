@@ -0,0 +1,68 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"module.resume/internal/domain"
+)
+
+func TestTranslateError(t *testing.T) {
+	assert.NoError(t, translateError(nil, "user not found"))
+
+	err := translateError(fmt.Errorf("query: %w", gorm.ErrRecordNotFound), "user not found")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, "user not found", err.Error())
+
+	err = translateError(&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "idx_user_email_active"}, "")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	assert.Equal(t, "email is already registered", err.Error())
+
+	err = translateError(&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "other"}, "")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	raw := errors.New("connection refused")
+	assert.Equal(t, raw, translateError(raw, "user not found"))
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Run("retries serialization failures", func(t *testing.T) {
+		calls := 0
+		err := withRetry(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return &pgconn.PgError{Code: pgSerializationFailure}
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		calls := 0
+		err := withRetry(context.Background(), func() error {
+			calls++
+			return &pgconn.PgError{Code: pgDeadlockDetected}
+		})
+
+		assert.Equal(t, maxRetries, calls)
+		assert.ErrorIs(t, translateError(err, ""), domain.ErrConflict)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		calls := 0
+		_ = withRetry(context.Background(), func() error {
+			calls++
+			return &pgconn.PgError{Code: pgUniqueViolation}
+		})
+
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,141 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/export"
+)
+
+const exportNotFound = "export not found"
+
+type UserExport struct {
+	ID          int64      `gorm:"column:id;primaryKey"`
+	UserID      uint       `gorm:"column:user_id"`
+	Status      string     `gorm:"column:status"`
+	Archive     []byte     `gorm:"column:archive"`
+	Error       *string    `gorm:"column:error"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+}
+
+func (UserExport) TableName() string {
+	return "user_export"
+}
+
+func (m UserExport) toDomain() *export.Export {
+	e := &export.Export{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Status:      m.Status,
+		Archive:     m.Archive,
+		CreatedAt:   m.CreatedAt,
+		CompletedAt: m.CompletedAt,
+		ExpiresAt:   m.ExpiresAt,
+	}
+	if m.Error != nil {
+		e.Error = *m.Error
+	}
+	return e
+}
+
+type ExportRepository struct {
+	db *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+var (
+	_ export.Repository          = (*ExportRepository)(nil)
+	_ application.UserDataEraser = (*ExportRepository)(nil)
+)
+
+func (r *ExportRepository) Create(ctx context.Context, e *export.Export) error {
+	m := &UserExport{UserID: e.UserID, Status: e.Status, CreatedAt: time.Now()}
+	if err := Conn(ctx, r.db).Create(m).Error; err != nil {
+		return translateError(err, exportNotFound)
+	}
+	e.ID, e.CreatedAt = m.ID, m.CreatedAt
+	return nil
+}
+
+func (r *ExportRepository) Find(ctx context.Context, id int64) (*export.Export, error) {
+	m := &UserExport{}
+	err := withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Where("id = ?", id).First(m).Error
+	})
+	if err != nil {
+		return nil, translateError(err, exportNotFound)
+	}
+	return m.toDomain(), nil
+}
+
+// FindPending 은 archive 를 읽지 않는다. 만들고 있는 중이라 비어 있다
+func (r *ExportRepository) FindPending(ctx context.Context, userID uint) (*export.Export, error) {
+	m := &UserExport{}
+	err := withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Omit("archive").
+			Where("user_id = ? AND status = ?", userID, export.StatusPending).
+			First(m).Error
+	})
+	if err != nil {
+		return nil, translateError(err, exportNotFound)
+	}
+	return m.toDomain(), nil
+}
+
+func (r *ExportRepository) MarkReady(ctx context.Context, id int64, archive []byte, expiresAt time.Time) error {
+	return r.complete(ctx, id, map[string]interface{}{
+		"status":     export.StatusReady,
+		"archive":    archive,
+		"expires_at": expiresAt,
+	})
+}
+
+func (r *ExportRepository) MarkFailed(ctx context.Context, id int64, reason string) error {
+	return r.complete(ctx, id, map[string]interface{}{
+		"status": export.StatusFailed,
+		"error":  reason,
+	})
+}
+
+func (r *ExportRepository) complete(ctx context.Context, id int64, values map[string]interface{}) error {
+	values["completed_at"] = time.Now()
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Model(&UserExport{}).
+			Where("id = ? AND status = ?", id, export.StatusPending).
+			Updates(values)
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return translateError(err, exportNotFound)
+	}
+	if rows == 0 {
+		return domain.NotFound(exportNotFound)
+	}
+	return nil
+}
+
+// PurgeExpired 는 before 전에 만료된 내보내기를 지운다. ZIP 에 개인정보가 있으므로 오래 두지 않는다
+func (r *ExportRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Where("expires_at < ? OR (status = ? AND completed_at < ?)", before, export.StatusFailed, before).
+			Delete(&UserExport{})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	return rows, translateError(err, exportNotFound)
+}
+
+func (r *ExportRepository) EraseUserData(ctx context.Context, userID uint) error {
+	return Conn(ctx, r.db).Where("user_id = ?", userID).Delete(&UserExport{}).Error
+}
@@ -0,0 +1,93 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"module.resume/internal/listing"
+)
+
+// applyListing 은 spec 의 필터, keyset 조건, 정렬을 q 에 건다
+// columns 는 listing 필드 이름 → 컬럼이고 id 가 있어야 한다. 정렬 값이 같은 행은 id 로 나눈다
+// 다음 페이지가 있는지 알 수 있게 Limit 보다 한 행 더 가져온다. 결과는 listingPage 로 자른다
+func applyListing(q *gorm.DB, spec listing.Spec, columns map[string]string) (*gorm.DB, error) {
+	column := func(field string) (string, error) {
+		c, ok := columns[field]
+		if !ok {
+			return "", fmt.Errorf("listing field %q has no column", field)
+		}
+		return c, nil
+	}
+	idColumn, err := column("id")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cond := range spec.Conditions {
+		c, err := column(cond.Field)
+		if err != nil {
+			return nil, err
+		}
+		switch cond.Op {
+		case listing.Eq:
+			q = q.Where(c+" = ?", cond.Value)
+		case listing.Prefix:
+			q = q.Where(c+" ILIKE ?", escapeLike(cond.Value.(string))+"%")
+		case listing.Contains:
+			q = q.Where(c+" ILIKE ?", "%"+escapeLike(cond.Value.(string))+"%")
+		case listing.Gte:
+			q = q.Where(c+" >= ?", cond.Value)
+		case listing.Lt:
+			q = q.Where(c+" < ?", cond.Value)
+		case listing.Present:
+			if cond.Value.(bool) {
+				q = q.Where(c + " IS NOT NULL")
+			} else {
+				q = q.Where(c + " IS NULL")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported listing op %q", cond.Op)
+		}
+	}
+
+	sortColumn, err := column(spec.Sort.Field)
+	if err != nil {
+		return nil, err
+	}
+	dir, cmp := "ASC", ">"
+	if spec.Sort.Desc {
+		dir, cmp = "DESC", "<"
+	}
+	if sortColumn == idColumn {
+		if spec.After != nil {
+			q = q.Where(idColumn+" "+cmp+" ?", spec.After.ID)
+		}
+		return q.Order(idColumn + " " + dir).Limit(spec.Limit + 1), nil
+	}
+	if spec.After != nil {
+		// 정렬 컬럼과 id 의 순서쌍 비교라서 (sort_column, id) 인덱스를 그대로 탈 수 있다
+		q = q.Where("("+sortColumn+", "+idColumn+") "+cmp+" (?, ?)", spec.After.Value, spec.After.ID)
+	}
+	return q.Order(sortColumn + " " + dir + ", " + idColumn + " " + dir).Limit(spec.Limit + 1), nil
+}
+
+// listingPage 는 한 행 더 가져온 결과를 Limit 으로 자르고, 남는 행이 있었으면 마지막 행의 Key 를 Next 로 둔다
+func listingPage[M, T any](rows []M, spec listing.Spec, toDomain func(M) T, key func(M) listing.Key) listing.Page[T] {
+	var next *listing.Key
+	if len(rows) > spec.Limit {
+		rows = rows[:spec.Limit]
+		last := key(rows[len(rows)-1])
+		next = &last
+	}
+	items := make([]T, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toDomain(row))
+	}
+	return listing.Page[T]{Items: items, Next: next}
+}
+
+// escapeLike 는 검색어의 %, _ 를 글자 그대로 찾게 한다
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,104 @@
+package gorm
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"module.resume/internal/domain/user"
+	"module.resume/internal/listing"
+)
+
+var testSchema = listing.Schema{
+	Fields: map[string]listing.Field{
+		"id":         {Kind: listing.Int, Sortable: true},
+		"created_at": {Kind: listing.Time, Sortable: true},
+		"email":      {Kind: listing.String},
+		"deleted_at": {Kind: listing.Time},
+	},
+	Filters: map[string]listing.Filter{
+		"email":   {Field: "email", Op: listing.Prefix},
+		"deleted": {Field: "deleted_at", Op: listing.Present},
+	},
+	DefaultSort:  "-created_at",
+	DefaultLimit: 2,
+	MaxLimit:     10,
+}
+
+// DB 없이 만들어지는 SQL 만 확인한다
+func TestApplyListing(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	codec := listing.NewCodec("secret")
+
+	query := url.Values{"email": {"a_b"}, "deleted": {"false"}}
+	spec, err := listing.Parse(query, testSchema, codec)
+	require.NoError(t, err)
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	query.Set(listing.ParamCursor, codec.Encode(spec, &listing.Key{Value: created, ID: 7}))
+	spec, err = listing.Parse(query, testSchema, codec)
+	require.NoError(t, err)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		q, err := applyListing(tx.Unscoped(), spec, userColumns)
+		require.NoError(t, err)
+		return q.Find(&[]User{})
+	})
+
+	assert.Contains(t, sql, `deleted_at IS NULL`)
+	assert.Contains(t, sql, `email ILIKE 'a\_b%'`)
+	assert.Contains(t, sql, `(created_at, id) < ('2024-05-01 12:00:00`)
+	assert.Contains(t, sql, `ORDER BY created_at DESC, id DESC LIMIT 3`)
+}
+
+func TestListingPage(t *testing.T) {
+	spec := listing.Spec{Limit: 2}
+	key := func(m User) listing.Key { return listing.Key{Value: int64(m.ID), ID: int64(m.ID)} }
+
+	page := listingPage([]User{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}, {Model: gorm.Model{ID: 3}}}, spec, User.toDomain, key)
+	require.Len(t, page.Items, 2)
+	require.NotNil(t, page.Next)
+	assert.Equal(t, int64(2), page.Next.ID)
+
+	page = listingPage([]User{{Model: gorm.Model{ID: 1}}}, spec, User.toDomain, key)
+	assert.Len(t, page.Items, 1)
+	assert.Nil(t, page.Next)
+}
+
+func TestUserRepository_Search(t *testing.T) {
+	db := testDB(t)
+	users := NewUserRepository(db)
+	ctx := context.Background()
+	prefix := "search-" + time.Now().Format("150405.000000")
+	t.Cleanup(func() { db.Exec(`DELETE FROM "user" WHERE email LIKE ?`, prefix+"%") })
+	for _, name := range []string{"a", "b", "c"} {
+		u, err := user.NewUserForSave(prefix+name+"@example.com", name, "password-1234", "")
+		require.NoError(t, err)
+		_, err = users.Save(ctx, u)
+		require.NoError(t, err)
+	}
+
+	// 두 개씩 끝까지 넘기면 세 명이 한 번씩 나온다
+	codec := listing.NewCodec("secret")
+	query := url.Values{"email": {prefix}, "limit": {"2"}}
+	var seen []string
+	for range 3 {
+		spec, err := listing.Parse(query, testSchema, codec)
+		require.NoError(t, err)
+		page, err := users.Search(ctx, spec)
+		require.NoError(t, err)
+		for _, u := range page.Items {
+			seen = append(seen, u.Name)
+		}
+		if page.Next == nil {
+			break
+		}
+		query.Set(listing.ParamCursor, codec.Encode(spec, page.Next))
+	}
+	assert.Equal(t, []string{"c", "b", "a"}, seen)
+}
@@ -0,0 +1,149 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+)
+
+// OutboxMessage 는 outbox 테이블의 한 행
+type OutboxMessage struct {
+	ID            int64           `gorm:"column:id;primaryKey" json:"id"`
+	EventName     string          `gorm:"column:event_name" json:"event"`
+	AggregateType string          `gorm:"column:aggregate_type" json:"aggregate_type"`
+	AggregateID   string          `gorm:"column:aggregate_id" json:"aggregate_id"`
+	Payload       json.RawMessage `gorm:"column:payload" json:"payload"`
+	OccurredAt    time.Time       `gorm:"column:occurred_at" json:"occurred_at"`
+	PublishedAt   *time.Time      `gorm:"column:published_at" json:"-"`
+	Attempts      int             `gorm:"column:attempts" json:"-"`
+	LastError     *string         `gorm:"column:last_error" json:"-"`
+	// NextAttemptAt 전에는 relay 가 읽지 않는다. 새 이벤트는 바로 읽는다
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;default:now()" json:"-"`
+	DeadAt        *time.Time `gorm:"column:dead_at" json:"-"`
+	// ClaimedUntil 까지는 가져간 relay 외에는 읽지 않는다
+	ClaimedUntil *time.Time `gorm:"column:claimed_until" json:"-"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+var (
+	_ application.Outbox           = (*OutboxRepository)(nil)
+	_ application.UserDataEraser   = (*OutboxRepository)(nil)
+	_ application.UserDataExporter = (*OutboxRepository)(nil)
+)
+
+// Append 는 ctx 의 트랜잭션 안에서 이벤트를 기록해 상태 변경과 함께 커밋되게 한다
+func (r *OutboxRepository) Append(ctx context.Context, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	messages := make([]OutboxMessage, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, OutboxMessage{
+			EventName:     event.EventName(),
+			AggregateType: event.AggregateType(),
+			AggregateID:   event.AggregateID(),
+			Payload:       payload,
+			OccurredAt:    event.OccurredAt(),
+		})
+	}
+	return Conn(ctx, r.db).Create(&messages).Error
+}
+
+// ClaimUnpublished 는 아직 전달되지 않았고 재시도 시각이 된 메시지에 claimedUntil 을 채우고 가져온다
+// 한 문장이라 그 자체로 짧은 트랜잭션이고, 잠금은 문장이 끝나면 풀린다
+// 다른 relay 가 가져간 행과 dead 로 옮긴 행은 건너뛴다
+func (r *OutboxRepository) ClaimUnpublished(ctx context.Context, limit int, claimedUntil time.Time) ([]OutboxMessage, error) {
+	var messages []OutboxMessage
+	err := Conn(ctx, r.db).Raw(`
+		UPDATE outbox SET claimed_until = ?
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE published_at IS NULL AND dead_at IS NULL AND next_attempt_at <= now()
+			  AND (claimed_until IS NULL OR claimed_until < now())
+			ORDER BY id
+			FOR UPDATE SKIP LOCKED
+			LIMIT ?
+		)
+		RETURNING *`,
+		claimedUntil, limit,
+	).Scan(&messages).Error
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, err
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	return Conn(ctx, r.db).Model(&OutboxMessage{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"published_at": time.Now(), "last_error": nil, "claimed_until": nil}).Error
+}
+
+// MarkFailed 는 실패를 기록하고 nextAttemptAt 까지 다시 읽지 않게 한다
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, cause error, nextAttemptAt time.Time) error {
+	return Conn(ctx, r.db).Model(&OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      cause.Error(),
+		"next_attempt_at": nextAttemptAt,
+		"claimed_until":   nil,
+	}).Error
+}
+
+// MarkDead 는 재시도를 포기한 메시지를 기록한다. dead_at 을 비우면 다시 전달된다
+func (r *OutboxRepository) MarkDead(ctx context.Context, id int64, cause error) error {
+	return Conn(ctx, r.db).Model(&OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":      gorm.Expr("attempts + 1"),
+		"last_error":    cause.Error(),
+		"dead_at":       time.Now(),
+		"claimed_until": nil,
+	}).Error
+}
+
+// EraseUserData 는 user 이벤트 payload 에 남은 개인정보(email)를 지운다
+func (r *OutboxRepository) EraseUserData(ctx context.Context, userID uint) error {
+	return Conn(ctx, r.db).Exec(
+		`UPDATE outbox SET payload = payload - 'email' WHERE aggregate_type = ? AND aggregate_id = ?`,
+		"user", strconv.FormatUint(uint64(userID), 10),
+	).Error
+}
+
+type exportedEvent struct {
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// ExportUserData 는 사용자 계정에 있었던 일(가입, 비밀번호 변경 등)을 시간순으로 내보낸다
+func (r *OutboxRepository) ExportUserData(ctx context.Context, userID uint) (string, interface{}, error) {
+	var messages []OutboxMessage
+	err := Conn(ctx, r.db).
+		Where("aggregate_type = ? AND aggregate_id = ?", "user", strconv.FormatUint(uint64(userID), 10)).
+		Order("id").
+		Find(&messages).Error
+	if err != nil {
+		return "", nil, err
+	}
+	events := make([]exportedEvent, 0, len(messages))
+	for _, msg := range messages {
+		events = append(events, exportedEvent{Event: msg.EventName, OccurredAt: msg.OccurredAt, Payload: msg.Payload})
+	}
+	return "account_events.json", events, nil
+}
@@ -0,0 +1,53 @@
+package gorm
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+)
+
+type txKey struct{}
+
+type TxManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+var _ application.TxManager = (*TxManager)(nil)
+
+// WithinTransaction 은 ctx 에 이미 트랜잭션이 있으면 그 트랜잭션에 합류한다
+// 가장 바깥 트랜잭션만 직렬화 실패/데드락 시 fn 전체를 다시 실행하므로 fn 은 재실행해도 안전해야 한다
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	return withRetry(ctx, func() error {
+		txCtx, runHooks := application.WithAfterCommitHooks(ctx)
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(txCtx, txKey{}, tx))
+		})
+		if err == nil {
+			runHooks()
+		}
+		return err
+	})
+}
+
+// Conn 은 ctx 에 트랜잭션이 있으면 그것을, 없으면 db 를 돌려준다
+// 저장소는 항상 이 함수로 쿼리를 시작해야 TxManager 의 트랜잭션에 참여한다
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
+}
@@ -0,0 +1,114 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/user"
+	"module.resume/internal/infrastructure/persistence/migrations"
+)
+
+// TEST_DATABASE_URL 이 있을 때만 실제 Postgres 에 대해 실행한다
+func testDB(t *testing.T) *gorm.DB {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := migrations.New(sqlDB, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return db
+}
+
+func TestTxManager(t *testing.T) {
+	db := testDB(t)
+	tx := NewTxManager(db)
+	users := NewUserRepository(db)
+	ctx := context.Background()
+	prefix := "tx-" + time.Now().Format("150405.000000")
+	t.Cleanup(func() { db.Exec(`DELETE FROM "user" WHERE email LIKE ?`, prefix+"%") })
+
+	newUser := func(t *testing.T, name string) *user.User {
+		u, err := user.NewUserForSave(prefix+name+"@example.com", name, "password-1234", "")
+		require.NoError(t, err)
+		return u
+	}
+	exists := func(t *testing.T, u *user.User) bool {
+		_, err := users.FindByEmail(ctx, u.Email)
+		if errors.Is(err, domain.ErrNotFound) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		u := newUser(t, "commit")
+		committed := false
+		err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			application.AfterCommit(ctx, func() { committed = true })
+			_, err := users.Save(ctx, u)
+			return err
+		})
+
+		require.NoError(t, err)
+		assert.True(t, committed)
+		assert.True(t, exists(t, u))
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		u := newUser(t, "rollback")
+		committed := false
+		err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			application.AfterCommit(ctx, func() { committed = true })
+			if _, err := users.Save(ctx, u); err != nil {
+				return err
+			}
+			// 트랜잭션 안에서는 방금 쓴 행이 보인다
+			_, err := users.FindByEmail(ctx, u.Email)
+			require.NoError(t, err)
+			return errors.New("boom")
+		})
+
+		assert.EqualError(t, err, "boom")
+		assert.False(t, committed)
+		assert.False(t, exists(t, u))
+	})
+
+	t.Run("nested call joins outer transaction", func(t *testing.T) {
+		outer, inner := newUser(t, "outer"), newUser(t, "inner")
+		err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := users.Save(ctx, outer); err != nil {
+				return err
+			}
+			return tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				if _, err := users.Save(ctx, inner); err != nil {
+					return err
+				}
+				return errors.New("inner failed")
+			})
+		})
+
+		assert.EqualError(t, err, "inner failed")
+		assert.False(t, exists(t, outer))
+		assert.False(t, exists(t, inner))
+	})
+}
@@ -9,10 +9,15 @@ import (
 
 type User struct {
 	gorm.Model
-	Email        string `gorm:"column:email;not null"`
+	Email        string `gorm:"column:email;not null;uniqueIndex:idx_user_email_active,where:deleted_at IS NULL"`
 	Name         string `gorm:"column:name;not null"`
 	PasswordHash string `gorm:"column:password_hash;not null"`
 	ProfileUrl   string `gorm:"column:profile_url"`
+	AvatarKey    string `gorm:"column:avatar_key"`
+	// TokensInvalidBefore 이전에 발급된 토큰은 모두 거부한다
+	TokensInvalidBefore *time.Time `gorm:"column:tokens_invalid_before"`
+	// AnonymizedAt 은 탈퇴 유예 기간이 끝나 개인정보를 지운 시각
+	AnonymizedAt *time.Time `gorm:"column:anonymized_at"`
 }
 
 func (User) TableName() string {
@@ -26,17 +31,34 @@ func (m User) toDomain() *user.User {
 	}
 
 	domainUser := &user.User{
-		ID:        m.ID,
-		Email:     m.Email,
-		Name:      m.Name,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
-		DeletedAt: deletedAt,
+		ID:         m.ID,
+		Email:      m.Email,
+		Name:       m.Name,
+		ProfileUrl: m.ProfileUrl,
+		AvatarKey:  m.AvatarKey,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+		DeletedAt:  deletedAt,
+
+		TokensInvalidBefore: m.TokensInvalidBefore,
 	}
 	domainUser.SetPasswordHash(m.PasswordHash)
 	return domainUser
 }
 
+// listingValue 는 정렬 필드의 값. 다음 페이지 커서에 들어간다
+func (m User) listingValue(field string) any {
+	switch field {
+	case "email":
+		return m.Email
+	case "name":
+		return m.Name
+	case "created_at":
+		return m.CreatedAt
+	}
+	return int64(m.ID)
+}
+
 func fromDomain(u *user.User) *User {
 	return &User{
 		Email:        u.Email,
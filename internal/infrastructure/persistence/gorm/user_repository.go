@@ -2,11 +2,18 @@ package gorm
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"module.resume/internal/domain"
 	"module.resume/internal/domain/user"
+	"module.resume/internal/listing"
 )
 
+const userNotFound = "user not found"
+
 type UserRepository struct {
 	db *gorm.DB
 }
@@ -15,33 +22,266 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{db}
 }
 
+func (r *UserRepository) FindByID(ctx context.Context, id uint) (*user.User, error) {
+	user := &User{}
+	err := withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Where("id = ?", id).First(user).Error
+	})
+	if err != nil {
+		return nil, translateError(err, userNotFound)
+	}
+	return user.toDomain(), nil
+}
+
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
 	user := &User{}
-	result := r.db.WithContext(ctx).Where("email = ?", email).First(user)
-	if err := result.Error; err != nil {
-		return nil, err
+	err := withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Where("email = ?", email).First(user).Error
+	})
+	if err != nil {
+		return nil, translateError(err, userNotFound)
 	}
 	return user.toDomain(), nil
 }
 
+func (r *UserRepository) FindCredentials(ctx context.Context, email string) (*user.User, error) {
+	return r.FindByEmail(ctx, email)
+}
+
 func (r *UserRepository) Save(ctx context.Context, user *user.User) (uint, error) {
 	gormUser := fromDomain(user)
-	err := r.db.WithContext(ctx).Create(gormUser).Error
+	err := Conn(ctx, r.db).Create(gormUser).Error
 	if err != nil {
-		return 0, err
+		return 0, translateError(err, userNotFound)
 	}
 	return gormUser.ID, nil
 }
 
+// Update 는 프로필만 바꾼다. email 은 확인을 거쳐 UpdateEmail 로만 바뀐다
+// 구조체 대신 map 으로 써서 GORM 이 빈 값을 건너뛰지 않게 한다
 func (r *UserRepository) Update(ctx context.Context, user *user.User) (uint, error) {
-	gormUser := fromDomain(user)
-	err := r.db.WithContext(ctx).Where("id = ?", user.ID).Updates(gormUser).Error
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Model(&User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"name":        user.Name,
+			"profile_url": user.ProfileUrl,
+		})
+		rows = result.RowsAffected
+		return result.Error
+	})
 	if err != nil {
-		return 0, err
+		return 0, translateError(err, userNotFound)
+	}
+	if rows == 0 {
+		return 0, domain.NotFound(userNotFound)
 	}
 	return user.ID, nil
 }
 
-func (r *UserRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&User{}, id).Error
+// UpdatePassword 는 비밀번호 해시와 토큰 무효화 시각만 바꾼다
+func (r *UserRepository) UpdatePassword(ctx context.Context, user *user.User) error {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Model(&User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"password_hash":         user.PasswordHash(),
+			"tokens_invalid_before": user.TokensInvalidBefore,
+		})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return translateError(err, userNotFound)
+	}
+	if rows == 0 {
+		return domain.NotFound(userNotFound)
+	}
+	return nil
+}
+
+// UpdateEmail 은 그 사이 같은 email 로 가입한 계정이 있으면 Conflict 를 돌려준다
+// email 이 이미 oldEmail 이 아니면 바꾸지 않고 NotFound 를 돌려준다
+func (r *UserRepository) UpdateEmail(ctx context.Context, user *user.User, oldEmail string) error {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Model(&User{}).Where("id = ? AND email = ?", user.ID, oldEmail).Updates(map[string]interface{}{
+			"email":                 user.Email,
+			"tokens_invalid_before": user.TokensInvalidBefore,
+		})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return translateError(err, userNotFound)
+	}
+	if rows == 0 {
+		return domain.NotFound(userNotFound)
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateAvatar(ctx context.Context, user *user.User) error {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Model(&User{}).Where("id = ?", user.ID).Update("avatar_key", user.AvatarKey)
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return translateError(err, userNotFound)
+	}
+	if rows == 0 {
+		return domain.NotFound(userNotFound)
+	}
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, user *user.User) error {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Model(&User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"deleted_at":            user.DeletedAt,
+			"tokens_invalid_before": user.TokensInvalidBefore,
+		})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return translateError(err, userNotFound)
+	}
+	if rows == 0 {
+		return domain.NotFound(userNotFound)
+	}
+	return nil
+}
+
+func (r *UserRepository) FindDeletedByEmail(ctx context.Context, email string) (*user.User, error) {
+	user := &User{}
+	err := withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Unscoped().
+			Where("email = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL", email).
+			Order("deleted_at DESC").
+			First(user).Error
+	})
+	if err != nil {
+		return nil, translateError(err, userNotFound)
+	}
+	return user.toDomain(), nil
+}
+
+// Restore 는 탈퇴를 취소한다. 그 사이 같은 email 로 가입한 계정이 있으면 Conflict 를 돌려준다
+func (r *UserRepository) Restore(ctx context.Context, user *user.User) error {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Unscoped().Model(&User{}).
+			Where("id = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL", user.ID).
+			Update("deleted_at", nil)
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return translateError(err, userNotFound)
+	}
+	if rows == 0 {
+		return domain.NotFound(userNotFound)
+	}
+	return nil
+}
+
+// FindAnonymizable 는 before 이전에 탈퇴했고 아직 익명화되지 않은 계정 ID 를 limit 개까지 돌려준다
+func (r *UserRepository) FindAnonymizable(ctx context.Context, before time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Unscoped().Model(&User{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ? AND anonymized_at IS NULL", before).
+			Order("deleted_at").
+			Limit(limit).
+			Pluck("id", &ids).Error
+	})
+	return ids, translateError(err, userNotFound)
+}
+
+// LockAnonymizable 는 아직 익명화할 수 있는 탈퇴 계정의 행을 잠근다. 트랜잭션 안에서 호출해야 한다
+// 그 사이 탈퇴를 취소했거나 이미 익명화된 계정이면 NotFound 를 돌려준다
+func (r *UserRepository) LockAnonymizable(ctx context.Context, id uint) error {
+	var ids []uint
+	err := withRetry(ctx, func() error {
+		return Conn(ctx, r.db).Unscoped().Model(&User{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL", id).
+			Pluck("id", &ids).Error
+	})
+	if err != nil {
+		return translateError(err, userNotFound)
+	}
+	if len(ids) == 0 {
+		return domain.NotFound(userNotFound)
+	}
+	return nil
+}
+
+// Anonymize 는 탈퇴 계정의 개인정보를 지운다. 행은 PurgeDeleted 가 지울 때까지 남는다
+// email 은 unique 제약 때문에 비울 수 없어서 ID 로 만든 주소로 바꾼다
+func (r *UserRepository) Anonymize(ctx context.Context, id uint) error {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Unscoped().Model(&User{}).
+			Where("id = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL", id).
+			Updates(map[string]interface{}{
+				"email":         fmt.Sprintf("erased-%d@invalid", id),
+				"name":          "",
+				"password_hash": "",
+				"profile_url":   "",
+				"avatar_key":    "",
+				"anonymized_at": time.Now(),
+			})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return translateError(err, userNotFound)
+	}
+	if rows == 0 {
+		return domain.NotFound(userNotFound)
+	}
+	return nil
+}
+
+// PurgeDeleted 는 before 이전에 탈퇴(soft delete)했고 익명화까지 끝난 계정을 완전히 삭제한다
+func (r *UserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var rows int64
+	err := withRetry(ctx, func() error {
+		result := Conn(ctx, r.db).Unscoped().
+			Where("deleted_at IS NOT NULL AND deleted_at < ? AND anonymized_at IS NOT NULL", before).
+			Delete(&User{})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	return rows, translateError(err, userNotFound)
+}
+
+// userColumns 는 관리자 사용자 검색의 필드 이름 → 컬럼
+var userColumns = map[string]string{
+	"id":         "id",
+	"email":      "email",
+	"name":       "name",
+	"created_at": "created_at",
+	"deleted_at": "deleted_at",
+}
+
+// Search 는 관리자용 사용자 검색. 탈퇴한 계정도 찾을 수 있도록 soft delete 된 행을 포함한다
+func (r *UserRepository) Search(ctx context.Context, spec listing.Spec) (listing.Page[*user.User], error) {
+	var rows []User
+	err := withRetry(ctx, func() error {
+		q, err := applyListing(Conn(ctx, r.db).Unscoped(), spec, userColumns)
+		if err != nil {
+			return err
+		}
+		return q.Find(&rows).Error
+	})
+	if err != nil {
+		return listing.Page[*user.User]{}, translateError(err, userNotFound)
+	}
+	return listingPage(rows, spec, User.toDomain, func(m User) listing.Key {
+		return listing.Key{Value: m.listingValue(spec.Sort.Field), ID: int64(m.ID)}
+	}), nil
 }
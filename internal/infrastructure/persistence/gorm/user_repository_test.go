@@ -0,0 +1,59 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+	"module.resume/internal/domain/user"
+)
+
+func TestUserRepository_Anonymize(t *testing.T) {
+	db := testDB(t)
+	tx := NewTxManager(db)
+	users := NewUserRepository(db)
+	ctx := context.Background()
+
+	save := func(t *testing.T, name string, deleted bool) uint {
+		u, err := user.NewUserForSave("anonymize-"+name+time.Now().Format("150405.000000")+"@example.com", name, "password-1234", "")
+		require.NoError(t, err)
+		id, err := users.Save(ctx, u)
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Exec(`DELETE FROM "user" WHERE id = ?`, id) })
+		if deleted {
+			u.ID = id
+			u.Delete()
+			require.NoError(t, users.Delete(ctx, u))
+		}
+		return id
+	}
+
+	t.Run("deleted user is locked and anonymized once", func(t *testing.T) {
+		id := save(t, "deleted", true)
+		err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := users.LockAnonymizable(ctx, id); err != nil {
+				return err
+			}
+			return users.Anonymize(ctx, id)
+		})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, users.Anonymize(ctx, id), domain.ErrNotFound)
+		err = tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			return users.LockAnonymizable(ctx, id)
+		})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("active user is not anonymizable", func(t *testing.T) {
+		id := save(t, "active", false)
+		err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			return users.LockAnonymizable(ctx, id)
+		})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.ErrorIs(t, users.Anonymize(ctx, id), domain.ErrNotFound)
+	})
+}
@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0010_later.up.sql":    {Data: []byte("SELECT 10")},
+			"0002_second.up.sql":   {Data: []byte("SELECT 2")},
+			"0002_second.down.sql": {Data: []byte("SELECT -2")},
+			"0001_first.up.sql":    {Data: []byte("")},
+		}
+
+		migrations, err := Load(fsys)
+
+		require.NoError(t, err)
+		require.Len(t, migrations, 3)
+		assert.Equal(t, []int64{1, 2, 10}, []int64{migrations[0].Version, migrations[1].Version, migrations[2].Version})
+		assert.Equal(t, "second", migrations[1].Name)
+		assert.Equal(t, "SELECT -2", migrations[1].Down)
+	})
+
+	t.Run("rejects bad input", func(t *testing.T) {
+		cases := map[string]fstest.MapFS{
+			"bad name":      {"first.up.sql": {}},
+			"missing up":    {"0001_first.down.sql": {}},
+			"name conflict": {"0001_a.up.sql": {}, "0001_b.down.sql": {}},
+		}
+		for name, fsys := range cases {
+			_, err := Load(fsys)
+			assert.Error(t, err, name)
+		}
+	})
+
+	t.Run("embedded migrations are valid", func(t *testing.T) {
+		_, err := New(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0007_existing.up.sql"), nil, 0o644))
+
+	files, err := Create(dir, "Add-Resume")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "0008_add_resume.up.sql"),
+		filepath.Join(dir, "0008_add_resume.down.sql"),
+	}, files)
+
+	_, err = Create(dir, "bad name!")
+	assert.Error(t, err)
+}
+
+// TEST_DATABASE_URL 이 있을 때만 실제 Postgres 에 대해 실행한다
+func TestMigrator_Postgres(t *testing.T) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sql.Open("pgx", url)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	fsys := fstest.MapFS{
+		"0001_widget.up.sql":   {Data: []byte("CREATE TABLE migration_test_widget (id INT); CREATE INDEX ON migration_test_widget (id);")},
+		"0001_widget.down.sql": {Data: []byte("DROP TABLE migration_test_widget")},
+		"0002_broken.up.sql":   {Data: []byte("CREATE TABLE migration_test_broken (id INT); SELECT * FROM does_not_exist;")},
+	}
+	m, err := NewWithFS(db, fsys, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = db.Exec(`DROP TABLE IF EXISTS migration_test_widget, migration_test_broken`)
+		_, _ = db.Exec(`DELETE FROM schema_migrations WHERE version IN (1, 2)`)
+	})
+
+	applied, err := m.Up(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 1, applied)
+
+	// 실패한 마이그레이션은 롤백되어 테이블도 기록도 남지 않는다
+	var exists bool
+	require.NoError(t, db.QueryRow(`SELECT to_regclass('migration_test_broken') IS NOT NULL`).Scan(&exists))
+	assert.False(t, exists)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.Nil(t, statuses[1].AppliedAt)
+
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+	require.NoError(t, db.QueryRow(`SELECT to_regclass('migration_test_widget') IS NOT NULL`).Scan(&exists))
+	assert.False(t, exists)
+}
@@ -0,0 +1,16 @@
+-- 기존에 손으로 만든 테이블이 있어도 그대로 이어서 쓸 수 있도록 IF NOT EXISTS 를 사용한다
+CREATE TABLE IF NOT EXISTS "user" (
+    id            BIGSERIAL PRIMARY KEY,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ,
+    deleted_at    TIMESTAMPTZ,
+    email         TEXT NOT NULL,
+    name          TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    profile_url   TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_deleted_at ON "user" (deleted_at);
+
+-- 탈퇴(soft delete)한 계정의 이메일은 다시 가입할 수 있다
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_email_active ON "user" (email) WHERE deleted_at IS NULL;
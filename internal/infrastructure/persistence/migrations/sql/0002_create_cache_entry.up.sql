@@ -0,0 +1,8 @@
+-- WAL 을 남길 필요가 없는 데이터라서 UNLOGGED 로 생성
+CREATE UNLOGGED TABLE IF NOT EXISTS cache_entry (
+    key        TEXT PRIMARY KEY,
+    value      TEXT NOT NULL,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_cache_entry_expires_at ON cache_entry (expires_at);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS batch_job_run (
+    id          BIGSERIAL PRIMARY KEY,
+    job_name    TEXT NOT NULL,
+    status      TEXT NOT NULL,
+    started_at  TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    error       TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_batch_job_run_job_name_started_at ON batch_job_run (job_name, started_at DESC);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS job_queue (
+    id              BIGSERIAL PRIMARY KEY,
+    kind            TEXT NOT NULL,
+    payload         JSONB NOT NULL DEFAULT '{}',
+    -- pending, running, done, dead
+    status          TEXT NOT NULL DEFAULT 'pending',
+    attempts        INT NOT NULL DEFAULT 0,
+    max_attempts    INT NOT NULL,
+    run_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_at       TIMESTAMPTZ,
+    last_error      TEXT,
+    idempotency_key TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- 워커가 꺼내갈 작업만 빠르게 찾는다
+CREATE INDEX IF NOT EXISTS idx_job_queue_ready ON job_queue (run_at, id) WHERE status IN ('pending', 'running');
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_queue_idempotency_key ON job_queue (idempotency_key) WHERE idempotency_key IS NOT NULL;
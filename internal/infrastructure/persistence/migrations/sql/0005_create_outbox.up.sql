@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id             BIGSERIAL PRIMARY KEY,
+    event_name     TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id   TEXT NOT NULL,
+    payload        JSONB NOT NULL,
+    occurred_at    TIMESTAMPTZ NOT NULL,
+    published_at   TIMESTAMPTZ,
+    attempts       INT NOT NULL DEFAULT 0,
+    last_error     TEXT
+);
+
+-- relay 는 아직 전달하지 않은 이벤트만 id 순서로 읽는다
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished ON outbox (id) WHERE published_at IS NULL;
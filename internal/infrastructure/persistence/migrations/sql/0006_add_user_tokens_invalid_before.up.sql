@@ -0,0 +1 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS tokens_invalid_before TIMESTAMPTZ;
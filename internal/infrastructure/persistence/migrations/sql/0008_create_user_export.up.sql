@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS user_export (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    -- pending, ready, failed
+    status       TEXT NOT NULL,
+    archive      BYTEA,
+    error        TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_export_user_id ON user_export (user_id);
+
+-- 사용자마다 만들고 있는 내보내기는 하나만 둔다
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_export_pending ON user_export (user_id) WHERE status = 'pending';
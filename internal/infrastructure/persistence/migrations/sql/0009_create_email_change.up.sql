@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS email_change (
+    id                 BIGSERIAL PRIMARY KEY,
+    user_id            BIGINT NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    old_email          TEXT NOT NULL,
+    new_email          TEXT NOT NULL,
+    -- 토큰 원문은 메일로만 보내고 sha256 해시만 저장한다
+    confirm_token_hash TEXT NOT NULL,
+    undo_token_hash    TEXT NOT NULL,
+    -- pending, confirmed, cancelled, undone
+    status             TEXT NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at         TIMESTAMPTZ NOT NULL,
+    undo_expires_at    TIMESTAMPTZ NOT NULL,
+    completed_at       TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_change_confirm_token ON email_change (confirm_token_hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_change_undo_token ON email_change (undo_token_hash);
+CREATE INDEX IF NOT EXISTS idx_email_change_user_id ON email_change (user_id);
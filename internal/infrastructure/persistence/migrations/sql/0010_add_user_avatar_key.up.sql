@@ -0,0 +1 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS avatar_key TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,31 @@
+-- 탈퇴 후 계정 행이 지워져도 기록은 남아야 하므로 user 를 참조하지 않는다
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    action      TEXT NOT NULL,
+    actor_id    BIGINT,
+    subject_id  BIGINT,
+    ip          TEXT,
+    user_agent  TEXT,
+    request_id  TEXT,
+    metadata    JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_subject_id ON audit_log (subject_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log (actor_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log (action, id);
+
+-- 기록은 바꾸거나 지울 수 없다. 탈퇴자 개인정보 삭제만 트랜잭션 안에서 audit.erasing 을 켜고 UPDATE 할 수 있다
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND current_setting('audit.erasing', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outbox_unpublished;
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS dead_at;
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished ON outbox (id) WHERE published_at IS NULL;
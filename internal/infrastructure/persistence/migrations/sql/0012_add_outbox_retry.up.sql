@@ -0,0 +1,7 @@
+-- 실패한 이벤트는 next_attempt_at 까지 건너뛰고, 재시도 횟수를 넘기면 dead_at 을 채우고 더 이상 읽지 않는다
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS dead_at         TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_unpublished;
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished ON outbox (id) WHERE published_at IS NULL AND dead_at IS NULL;
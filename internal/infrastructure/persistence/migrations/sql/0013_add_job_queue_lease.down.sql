@@ -0,0 +1,3 @@
+ALTER TABLE job_queue
+    DROP COLUMN IF EXISTS locked_by,
+    DROP COLUMN IF EXISTS lease_until;
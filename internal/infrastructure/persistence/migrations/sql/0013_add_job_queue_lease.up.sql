@@ -0,0 +1,4 @@
+-- 작업을 꺼낸 워커만 결과를 기록하도록 꺼낼 때마다 새 토큰과 lease 만료 시각을 남긴다
+ALTER TABLE job_queue
+    ADD COLUMN IF NOT EXISTS locked_by   TEXT,
+    ADD COLUMN IF NOT EXISTS lease_until TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+-- relay 는 짧은 트랜잭션으로 claimed_until 을 채워 메시지를 가져가고, 전달은 트랜잭션 밖에서 한다
+-- relay 가 죽으면 claimed_until 이 지난 뒤 다른 relay 가 다시 가져간다
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
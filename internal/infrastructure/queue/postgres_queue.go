@@ -0,0 +1,164 @@
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+	persistence "module.resume/internal/infrastructure/persistence/gorm"
+)
+
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	// StatusDead 는 재시도를 모두 소진한 작업 (dead letter)
+	StatusDead = "dead"
+)
+
+const (
+	defaultMaxAttempts = 5
+	baseBackoff        = 10 * time.Second
+	maxBackoff         = time.Hour
+)
+
+// ErrLeaseLost 는 lease 가 끝나 다른 워커가 작업을 가져갔거나 가져갈 수 있게 된 뒤에 결과를 기록하려 할 때 반환된다
+var ErrLeaseLost = errors.New("job lease expired or taken by another worker")
+
+// Job 은 워커가 꺼내간 작업
+type Job struct {
+	ID          int64           `gorm:"column:id"`
+	Kind        string          `gorm:"column:kind"`
+	Payload     json.RawMessage `gorm:"column:payload"`
+	Attempts    int             `gorm:"column:attempts"`
+	MaxAttempts int             `gorm:"column:max_attempts"`
+	// LockedBy 는 이번에 꺼낼 때 만든 토큰. Complete/Fail 은 이 토큰이 그대로일 때만 기록한다
+	LockedBy string `gorm:"column:locked_by"`
+}
+
+// PostgresQueue 는 별도 브로커 없이 job_queue 테이블을 큐로 쓴다
+// 여러 워커가 동시에 꺼내도 FOR UPDATE SKIP LOCKED 로 같은 작업을 두 번 가져가지 않는다
+type PostgresQueue struct {
+	db *gorm.DB
+}
+
+func NewPostgresQueue(db *gorm.DB) *PostgresQueue {
+	return &PostgresQueue{db: db}
+}
+
+var _ application.JobQueue = (*PostgresQueue)(nil)
+
+func (q *PostgresQueue) Enqueue(ctx context.Context, task application.Task) error {
+	if task.Kind == "" {
+		return errors.New("task kind is required")
+	}
+	payload, err := json.Marshal(task.Payload)
+	if err != nil {
+		return err
+	}
+	maxAttempts := task.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	runAt := task.RunAt
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+	var key *string
+	if task.IdempotencyKey != "" {
+		key = &task.IdempotencyKey
+	}
+
+	// 같은 idempotency key 가 이미 있으면 아무것도 하지 않는다
+	// 트랜잭션 안에서 호출하면 상태 변경과 함께 커밋될 때만 작업이 등록된다
+	return persistence.Conn(ctx, q.db).Exec(`
+		INSERT INTO job_queue (kind, payload, max_attempts, run_at, idempotency_key)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING`,
+		task.Kind, string(payload), maxAttempts, runAt, key,
+	).Error
+}
+
+// Dequeue 는 실행할 작업 하나를 running 으로 바꾸고 돌려준다. 없으면 nil
+// lease 가 지나도록 running 인 작업은 워커가 죽은 것으로 보고 다시 가져간다
+func (q *PostgresQueue) Dequeue(ctx context.Context, kinds []string, lease time.Duration) (*Job, error) {
+	token, err := newLeaseToken()
+	if err != nil {
+		return nil, err
+	}
+	var jobs []Job
+	err = q.db.WithContext(ctx).Raw(`
+		UPDATE job_queue SET status = ?, locked_at = now(), locked_by = ?, lease_until = ?,
+			attempts = attempts + 1, updated_at = now()
+		WHERE id = (
+			SELECT id FROM job_queue
+			WHERE kind IN ?
+			  AND ((status = ? AND run_at <= now()) OR (status = ? AND lease_until < now()))
+			ORDER BY run_at, id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING id, kind, payload, attempts, max_attempts, locked_by`,
+		StatusRunning, token, time.Now().Add(lease), kinds, StatusPending, StatusRunning,
+	).Scan(&jobs).Error
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return &jobs[0], nil
+}
+
+func (q *PostgresQueue) Complete(ctx context.Context, job *Job) error {
+	return q.release(ctx,
+		`UPDATE job_queue SET status = ?, locked_at = NULL, locked_by = NULL, lease_until = NULL, last_error = NULL, updated_at = now()
+		WHERE id = ? AND status = ? AND locked_by = ? AND lease_until > now()`,
+		StatusDone, job.ID, StatusRunning, job.LockedBy,
+	)
+}
+
+// Fail 은 재시도 횟수가 남아 있으면 backoff 뒤에 다시 실행하도록, 아니면 dead 로 옮긴다
+func (q *PostgresQueue) Fail(ctx context.Context, job *Job, cause error) error {
+	status, runAt := StatusPending, time.Now().Add(Backoff(job.Attempts))
+	if job.Attempts >= job.MaxAttempts {
+		status = StatusDead
+	}
+	return q.release(ctx,
+		`UPDATE job_queue SET status = ?, run_at = ?, locked_at = NULL, locked_by = NULL, lease_until = NULL, last_error = ?, updated_at = now()
+		WHERE id = ? AND status = ? AND locked_by = ? AND lease_until > now()`,
+		status, runAt, cause.Error(), job.ID, StatusRunning, job.LockedBy,
+	)
+}
+
+// release 는 아직 lease 를 가진 경우에만 결과를 기록한다
+// lease 가 끝난 뒤라면 다른 워커가 이미 다시 실행 중일 수 있으므로 덮어쓰지 않고 ErrLeaseLost 를 돌려준다
+func (q *PostgresQueue) release(ctx context.Context, sql string, args ...interface{}) error {
+	result := q.db.WithContext(ctx).Exec(sql, args...)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func newLeaseToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Backoff 는 attempt 번째 실패 후 기다릴 시간. 10초부터 두 배씩 늘고 1시간을 넘지 않는다
+func Backoff(attempt int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
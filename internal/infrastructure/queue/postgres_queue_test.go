@@ -0,0 +1,141 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"module.resume/internal/application"
+	"module.resume/internal/infrastructure/persistence/migrations"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, Backoff(1))
+	assert.Equal(t, 20*time.Second, Backoff(2))
+	assert.Equal(t, 80*time.Second, Backoff(4))
+	assert.Equal(t, time.Hour, Backoff(30))
+}
+
+// TEST_DATABASE_URL 이 있을 때만 실제 Postgres 에 대해 실행한다
+func testQueue(t *testing.T) (*PostgresQueue, *gorm.DB) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := migrations.New(sqlDB, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return NewPostgresQueue(db), db
+}
+
+func TestPostgresQueue(t *testing.T) {
+	q, db := testQueue(t)
+	ctx := context.Background()
+	kind := "test-" + time.Now().Format("150405.000000")
+	t.Cleanup(func() { db.Exec(`DELETE FROM job_queue WHERE kind = ?`, kind) })
+
+	t.Run("idempotency key", func(t *testing.T) {
+		task := application.Task{Kind: kind, Payload: map[string]string{"n": "1"}, IdempotencyKey: kind + ":once", MaxAttempts: 2}
+		require.NoError(t, q.Enqueue(ctx, task))
+		require.NoError(t, q.Enqueue(ctx, task))
+
+		var count int64
+		require.NoError(t, db.Table("job_queue").Where("kind = ?", kind).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("concurrent workers never share a job", func(t *testing.T) {
+		for range 5 {
+			require.NoError(t, q.Enqueue(ctx, application.Task{Kind: kind}))
+		}
+
+		var mu sync.Mutex
+		seen := map[int64]int{}
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					job, err := q.Dequeue(ctx, []string{kind}, time.Minute)
+					if err != nil || job == nil {
+						return
+					}
+					mu.Lock()
+					seen[job.ID]++
+					mu.Unlock()
+					assert.NoError(t, q.Complete(ctx, job))
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, seen, 6)
+		for id, n := range seen {
+			assert.Equal(t, 1, n, "job %d", id)
+		}
+	})
+
+	t.Run("expired lease cannot record a result", func(t *testing.T) {
+		require.NoError(t, q.Enqueue(ctx, application.Task{Kind: kind}))
+
+		stale, err := q.Dequeue(ctx, []string{kind}, time.Millisecond)
+		require.NoError(t, err)
+		require.NotNil(t, stale)
+		time.Sleep(20 * time.Millisecond)
+
+		// lease 가 끝난 작업은 다른 워커가 새 토큰으로 다시 가져간다
+		current, err := q.Dequeue(ctx, []string{kind}, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, current)
+		assert.Equal(t, stale.ID, current.ID)
+		assert.NotEqual(t, stale.LockedBy, current.LockedBy)
+
+		assert.ErrorIs(t, q.Complete(ctx, stale), ErrLeaseLost)
+		assert.ErrorIs(t, q.Fail(ctx, stale, errors.New("late")), ErrLeaseLost)
+		require.NoError(t, q.Complete(ctx, current))
+		assert.ErrorIs(t, q.Complete(ctx, current), ErrLeaseLost)
+	})
+
+	t.Run("retry then dead letter", func(t *testing.T) {
+		require.NoError(t, q.Enqueue(ctx, application.Task{Kind: kind, MaxAttempts: 2}))
+
+		job, err := q.Dequeue(ctx, []string{kind}, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		require.NoError(t, q.Fail(ctx, job, errors.New("first")))
+
+		// backoff 중에는 다시 꺼내지지 않는다
+		again, err := q.Dequeue(ctx, []string{kind}, time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, again)
+
+		require.NoError(t, db.Exec(`UPDATE job_queue SET run_at = now() WHERE id = ?`, job.ID).Error)
+		job, err = q.Dequeue(ctx, []string{kind}, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, 2, job.Attempts)
+		require.NoError(t, q.Fail(ctx, job, errors.New("second")))
+
+		var status, lastError string
+		require.NoError(t, db.Raw(`SELECT status, last_error FROM job_queue WHERE id = ?`, job.ID).Row().Scan(&status, &lastError))
+		assert.Equal(t, StatusDead, status)
+		assert.Equal(t, "second", lastError)
+	})
+}
@@ -0,0 +1,64 @@
+package listing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Codec 은 Key 를 클라이언트에 넘길 불투명한 커서로 만든다
+// 서명에 정렬과 필터를 함께 넣어서 커서를 고치거나 다른 조건의 목록에 쓰면 거부한다
+type Codec struct {
+	key []byte
+}
+
+func NewCodec(key string) *Codec {
+	return &Codec{key: []byte(key)}
+}
+
+type cursorPayload struct {
+	Value string `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+// Encode 는 spec 으로 조회한 페이지의 다음 커서. 마지막 페이지(next 가 nil)면 빈 문자열
+func (c *Codec) Encode(spec Spec, next *Key) string {
+	if next == nil {
+		return ""
+	}
+	payload, _ := json.Marshal(cursorPayload{Value: formatValue(next.Value), ID: next.ID})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + c.mac(spec.query, encoded)
+}
+
+func (c *Codec) decode(token string, spec Spec) (*Key, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(c.mac(spec.query, encoded))) {
+		return nil, errInvalidCursor
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, errInvalidCursor
+	}
+	value, err := parseValue(spec.kind, payload.Value)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	return &Key{Value: value, ID: payload.ID}, nil
+}
+
+// mac 앞에 용도를 붙여서 같은 키를 쓰는 다른 서명(다운로드 링크 등)과 섞이지 않게 한다
+func (c *Codec) mac(query, encoded string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte("listing-cursor\n" + query + "\n" + encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,256 @@
+package listing
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"module.resume/internal/domain"
+)
+
+// 목록 API 가 공통으로 쓰는 쿼리 파라미터
+const (
+	ParamLimit  = "limit"
+	ParamCursor = "cursor"
+	ParamSort   = "sort"
+)
+
+// Kind 는 필드 값의 타입. 쿼리 파라미터와 커서의 문자열을 이 타입으로 해석한다
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Time
+	Bool
+)
+
+type Op string
+
+const (
+	Eq Op = "eq"
+	// Prefix 와 Contains 는 대소문자를 구분하지 않는다
+	Prefix   Op = "prefix"
+	Contains Op = "contains"
+	Gte      Op = "gte"
+	Lt       Op = "lt"
+	// Present 는 true 면 값이 있는 행, false 면 값이 없는(NULL) 행
+	Present Op = "present"
+)
+
+type Field struct {
+	Kind Kind
+	// Sortable 한 필드는 keyset 비교를 하므로 NULL 이 없어야 한다
+	Sortable bool
+}
+
+// Filter 는 쿼리 파라미터 하나가 어느 필드에 어떤 조건을 거는지
+type Filter struct {
+	Field string
+	Op    Op
+}
+
+// Schema 는 목록 API 하나가 받는 필드, 필터, 정렬. 필드 이름은 API 에 보이는 이름이다
+// 정렬이 같은 행은 id 순서로 나누므로 Fields 에는 Int 인 id 가 있어야 한다
+type Schema struct {
+	Fields map[string]Field
+	// Filters 의 키가 쿼리 파라미터 이름
+	Filters map[string]Filter
+	// DefaultSort 는 sort 가 없을 때 쓴다. "-" 로 시작하면 내림차순
+	DefaultSort  string
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Condition 은 검증을 마친 필터 조건. Value 는 필드 Kind 에 맞는 string, int64, time.Time, bool 이다
+type Condition struct {
+	Field string
+	Op    Op
+	Value any
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// Key 는 페이지 마지막 행의 정렬 값과 id. 다음 페이지는 이 행 바로 뒤부터 시작한다
+type Key struct {
+	Value any
+	ID    int64
+}
+
+// Spec 은 검증을 마친 목록 조회 조건
+type Spec struct {
+	Limit      int
+	Sort       Sort
+	Conditions []Condition
+	// After 가 nil 이면 첫 페이지
+	After *Key
+
+	kind Kind
+	// query 는 정렬과 필터를 정규화한 문자열. 다른 조건으로 만든 커서를 거부하는 데 쓴다
+	query string
+}
+
+// Query 는 정렬과 필터를 정규화한 쿼리 문자열 (예: -created_at?email=kim)
+func (s Spec) Query() string {
+	return s.query
+}
+
+// Page 는 한 페이지 결과. Next 가 nil 이면 마지막 페이지다
+type Page[T any] struct {
+	Items []T
+	Next  *Key
+}
+
+// Parse 는 쿼리 파라미터를 schema 로 검증해 Spec 을 만든다. 모르는 파라미터도 오타일 수 있어 거부한다
+func Parse(query url.Values, schema Schema, codec *Codec) (Spec, error) {
+	var fields []domain.FieldError
+	invalid := func(field, format string, args ...any) {
+		fields = append(fields, domain.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	spec := Spec{Limit: schema.DefaultLimit}
+	if v := query.Get(ParamLimit); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > schema.MaxLimit {
+			invalid(ParamLimit, "must be between 1 and %d", schema.MaxLimit)
+		}
+		spec.Limit = limit
+	}
+
+	sort := query.Get(ParamSort)
+	if sort == "" {
+		sort = schema.DefaultSort
+	}
+	spec.Sort = Sort{Field: strings.TrimPrefix(sort, "-"), Desc: strings.HasPrefix(sort, "-")}
+	if field, ok := schema.Fields[spec.Sort.Field]; ok && field.Sortable {
+		spec.kind = field.Kind
+	} else {
+		invalid(ParamSort, "must be one of %s, optionally prefixed with -", strings.Join(schema.sortable(), ", "))
+	}
+
+	filters := url.Values{}
+	for name, values := range query {
+		if name == ParamLimit || name == ParamCursor || name == ParamSort {
+			continue
+		}
+		filter, ok := schema.Filters[name]
+		if !ok {
+			invalid(name, "is not a supported filter")
+			continue
+		}
+		if len(values) != 1 {
+			invalid(name, "must be given once")
+			continue
+		}
+		value, err := parseFilter(schema.Fields[filter.Field].Kind, filter.Op, values[0])
+		if err != nil {
+			invalid(name, "%s", err.Error())
+			continue
+		}
+		filters.Set(name, values[0])
+		spec.Conditions = append(spec.Conditions, Condition{Field: filter.Field, Op: filter.Op, Value: value})
+	}
+	// map 순회 순서와 상관없이 같은 쿼리는 같은 SQL 이 되게 한다
+	slices.SortFunc(spec.Conditions, func(a, b Condition) int {
+		return strings.Compare(a.Field+string(a.Op), b.Field+string(b.Op))
+	})
+	spec.query = spec.Sort.String() + "?" + filters.Encode()
+
+	if len(fields) > 0 {
+		return Spec{}, domain.Validation("request validation failed", fields...)
+	}
+
+	if token := query.Get(ParamCursor); token != "" {
+		after, err := codec.decode(token, spec)
+		if err != nil {
+			return Spec{}, domain.Validation("request validation failed",
+				domain.FieldError{Field: ParamCursor, Message: "is invalid or does not match the current sort and filters"})
+		}
+		spec.After = after
+	}
+	return spec, nil
+}
+
+// NextLink 는 요청 URL 에 다음 페이지 커서를 넣은 Link 헤더 값(RFC 8288)
+func NextLink(u *url.URL, cursor string) string {
+	query := u.Query()
+	query.Set(ParamCursor, cursor)
+	next := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return "<" + next.String() + `>; rel="next"`
+}
+
+func (s Schema) sortable() []string {
+	var names []string
+	for name, field := range s.Fields {
+		if field.Sortable {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func parseFilter(kind Kind, op Op, v string) (any, error) {
+	switch op {
+	case Prefix, Contains:
+		if strings.TrimSpace(v) == "" {
+			return nil, errors.New("must not be empty")
+		}
+		return v, nil
+	case Present:
+		return parseValue(Bool, v)
+	}
+	return parseValue(kind, v)
+}
+
+func parseValue(kind Kind, v string) (any, error) {
+	switch kind {
+	case Int:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be an integer")
+		}
+		return n, nil
+	case Time:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, errors.New("must be an RFC 3339 timestamp")
+		}
+		return t, nil
+	case Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return b, nil
+	}
+	return v, nil
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
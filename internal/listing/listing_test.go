@@ -0,0 +1,117 @@
+package listing
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"module.resume/internal/domain"
+)
+
+var testSchema = Schema{
+	Fields: map[string]Field{
+		"id":         {Kind: Int, Sortable: true},
+		"email":      {Kind: String, Sortable: true},
+		"created_at": {Kind: Time, Sortable: true},
+		"deleted_at": {Kind: Time},
+	},
+	Filters: map[string]Filter{
+		"email":         {Field: "email", Op: Prefix},
+		"created_after": {Field: "created_at", Op: Gte},
+		"deleted":       {Field: "deleted_at", Op: Present},
+	},
+	DefaultSort:  "-created_at",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+func TestParse(t *testing.T) {
+	codec := NewCodec("secret")
+
+	t.Run("defaults", func(t *testing.T) {
+		spec, err := Parse(url.Values{}, testSchema, codec)
+
+		require.NoError(t, err)
+		assert.Equal(t, 20, spec.Limit)
+		assert.Equal(t, Sort{Field: "created_at", Desc: true}, spec.Sort)
+		assert.Empty(t, spec.Conditions)
+		assert.Nil(t, spec.After)
+	})
+
+	t.Run("typed filters", func(t *testing.T) {
+		spec, err := Parse(url.Values{
+			"limit": {"5"}, "sort": {"email"},
+			"created_after": {"2024-05-01T00:00:00Z"}, "deleted": {"true"}, "email": {"kim"},
+		}, testSchema, codec)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, spec.Limit)
+		assert.Equal(t, Sort{Field: "email"}, spec.Sort)
+		assert.Equal(t, []Condition{
+			{Field: "created_at", Op: Gte, Value: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+			{Field: "deleted_at", Op: Present, Value: true},
+			{Field: "email", Op: Prefix, Value: "kim"},
+		}, spec.Conditions)
+	})
+
+	t.Run("invalid params are reported together", func(t *testing.T) {
+		_, err := Parse(url.Values{
+			"limit": {"500"}, "sort": {"deleted_at"}, "created_after": {"yesterday"}, "role": {"admin"},
+		}, testSchema, codec)
+
+		var domainErr *domain.Error
+		require.ErrorAs(t, err, &domainErr)
+		var fields []string
+		for _, f := range domainErr.Fields {
+			fields = append(fields, f.Field)
+		}
+		assert.ElementsMatch(t, []string{"limit", "sort", "created_after", "role"}, fields)
+	})
+}
+
+func TestCursor(t *testing.T) {
+	codec := NewCodec("secret")
+	query := url.Values{"email": {"kim"}}
+	spec, err := Parse(query, testSchema, codec)
+	require.NoError(t, err)
+	created := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)
+	cursor := codec.Encode(spec, &Key{Value: created, ID: 42})
+
+	t.Run("round trip", func(t *testing.T) {
+		next, err := Parse(url.Values{"email": {"kim"}, "cursor": {cursor}}, testSchema, codec)
+
+		require.NoError(t, err)
+		require.NotNil(t, next.After)
+		assert.Equal(t, int64(42), next.After.ID)
+		assert.True(t, created.Equal(next.After.Value.(time.Time)))
+	})
+
+	t.Run("rejected with other filters, sort or key", func(t *testing.T) {
+		payload, signature, _ := strings.Cut(cursor, ".")
+		for _, query := range []url.Values{
+			{"email": {"lee"}, "cursor": {cursor}},
+			{"email": {"kim"}, "sort": {"created_at"}, "cursor": {cursor}},
+			{"email": {"kim"}, "cursor": {payload + "x." + signature}},
+			{"email": {"kim"}, "cursor": {cursor}, "deleted": {"true"}},
+		} {
+			_, err := Parse(query, testSchema, NewCodec("secret"))
+			assert.ErrorIs(t, err, domain.ErrValidation, query.Encode())
+		}
+		_, err := Parse(url.Values{"email": {"kim"}, "cursor": {cursor}}, testSchema, NewCodec("other"))
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+
+	t.Run("no cursor on the last page", func(t *testing.T) {
+		assert.Empty(t, codec.Encode(spec, nil))
+	})
+}
+
+func TestNextLink(t *testing.T) {
+	u, err := url.Parse("/admin/users?email=kim&cursor=old")
+	require.NoError(t, err)
+
+	assert.Equal(t, `</admin/users?cursor=new&email=kim>; rel="next"`, NextLink(u, "new"))
+}